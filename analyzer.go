@@ -0,0 +1,137 @@
+package go_tf_idf
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+type Tokenizer interface {
+	Tokenize(s string) []string
+}
+
+type TokenizerFunc func(s string) []string
+
+func (f TokenizerFunc) Tokenize(s string) []string {
+	return f(s)
+}
+
+var (
+	DefaultTokenizer    Tokenizer = TokenizerFunc(Tokenize)
+	WhitespaceTokenizer Tokenizer = TokenizerFunc(strings.Fields)
+	UnicodeTokenizer    Tokenizer = TokenizerFunc(TokenizeUnicode)
+)
+
+// CharFilter transforms text before it is tokenized.
+type CharFilter func(s string) string
+
+// TokenFilter transforms, adds or removes tokens after tokenization.
+type TokenFilter func(tokens []string) []string
+
+// Analyzer turns text into tokens by running its char filters, its tokenizer
+// and its token filters in order. An Analyzer is itself a Tokenizer.
+type Analyzer struct {
+	CharFilters  []CharFilter
+	Tokenizer    Tokenizer
+	TokenFilters []TokenFilter
+}
+
+func NewAnalyzer(tokenizer Tokenizer) *Analyzer {
+	return &Analyzer{
+		CharFilters:  make([]CharFilter, 0),
+		Tokenizer:    tokenizer,
+		TokenFilters: make([]TokenFilter, 0),
+	}
+}
+
+func (a *Analyzer) Tokenize(s string) []string {
+	for _, filter := range a.CharFilters {
+		s = filter(s)
+	}
+
+	tokenizer := a.Tokenizer
+	if tokenizer == nil {
+		tokenizer = DefaultTokenizer
+	}
+	tokens := tokenizer.Tokenize(s)
+
+	for _, filter := range a.TokenFilters {
+		tokens = filter(tokens)
+	}
+
+	return tokens
+}
+
+// WithAnalyzer analyzes text with a copy of analyzer, so that analyzer may be
+// shared with other TfIdf instances and options such as WithTokenizer do not
+// change it. The option is ignored if analyzer is nil.
+func WithAnalyzer(analyzer *Analyzer) Option {
+	return func(tfIdf *TfIdf) {
+		if analyzer != nil {
+			tfIdf.analyzer = analyzer.clone()
+		}
+	}
+}
+
+// clone returns a copy of the analyzer with its own filter slices.
+func (a *Analyzer) clone() *Analyzer {
+	return &Analyzer{
+		CharFilters:  append(make([]CharFilter, 0, len(a.CharFilters)), a.CharFilters...),
+		Tokenizer:    a.Tokenizer,
+		TokenFilters: append(make([]TokenFilter, 0, len(a.TokenFilters)), a.TokenFilters...),
+	}
+}
+
+func WithTokenizer(tokenizer Tokenizer) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.analyzer.Tokenizer = tokenizer
+	}
+}
+
+func WithCharFilters(filters ...CharFilter) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.analyzer.CharFilters = append(tfIdf.analyzer.CharFilters, filters...)
+	}
+}
+
+func WithTokenFilters(filters ...TokenFilter) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.analyzer.TokenFilters = append(tfIdf.analyzer.TokenFilters, filters...)
+	}
+}
+
+func LowercaseCharFilter(s string) string {
+	return strings.ToLower(s)
+}
+
+// ReplaceCharFilter returns a CharFilter replacing every old string with its
+// new string, given as old, new pairs like strings.NewReplacer.
+func ReplaceCharFilter(oldnew ...string) CharFilter {
+	replacer := strings.NewReplacer(oldnew...)
+	return replacer.Replace
+}
+
+func LowercaseFilter(tokens []string) []string {
+	for index, token := range tokens {
+		tokens[index] = strings.ToLower(token)
+	}
+
+	return tokens
+}
+
+// LengthFilter returns a TokenFilter dropping tokens with fewer than min or,
+// if max is positive, more than max runes.
+func LengthFilter(min, max int) TokenFilter {
+	return func(tokens []string) []string {
+		kept := tokens[:0]
+		for _, token := range tokens {
+			length := utf8.RuneCountInString(token)
+			if length < min || (max > 0 && length > max) {
+				continue
+			}
+
+			kept = append(kept, token)
+		}
+
+		return kept
+	}
+}
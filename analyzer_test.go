@@ -0,0 +1,175 @@
+package go_tf_idf
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAnalyzer_Tokenize(t *testing.T) {
+	tests := []struct {
+		name     string
+		analyzer *Analyzer
+		s        string
+		want     []string
+	}{
+		{
+			name:     "default tokenizer",
+			analyzer: NewAnalyzer(DefaultTokenizer),
+			s:        "Two parts.",
+			want:     []string{"two", "parts"},
+		},
+		{
+			name:     "nil tokenizer",
+			analyzer: &Analyzer{},
+			s:        "Two parts.",
+			want:     []string{"two", "parts"},
+		},
+		{
+			name:     "whitespace tokenizer",
+			analyzer: NewAnalyzer(WhitespaceTokenizer),
+			s:        "Two parts.",
+			want:     []string{"Two", "parts."},
+		},
+		{
+			name: "char filters",
+			analyzer: &Analyzer{
+				CharFilters: []CharFilter{
+					LowercaseCharFilter,
+					ReplaceCharFilter("-", " ", "!", ""),
+				},
+				Tokenizer: WhitespaceTokenizer,
+			},
+			s:    "Well-Known fact!",
+			want: []string{"well", "known", "fact"},
+		},
+		{
+			name: "token filters",
+			analyzer: &Analyzer{
+				Tokenizer: WhitespaceTokenizer,
+				TokenFilters: []TokenFilter{
+					LowercaseFilter,
+					LengthFilter(2, 4),
+				},
+			},
+			s:    "A Small SENTENCE of words",
+			want: []string{"of"},
+		},
+		{
+			name: "custom tokenizer",
+			analyzer: NewAnalyzer(TokenizerFunc(func(s string) []string {
+				return strings.Split(s, "|")
+			})),
+			s:    "a b|c",
+			want: []string{"a b", "c"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.analyzer.Tokenize(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLengthFilter(t *testing.T) {
+	tests := []struct {
+		name   string
+		min    int
+		max    int
+		tokens []string
+		want   []string
+	}{
+		{
+			name:   "min only",
+			min:    2,
+			tokens: []string{"a", "ab", "abcdef"},
+			want:   []string{"ab", "abcdef"},
+		},
+		{
+			name:   "counts runes",
+			min:    2,
+			max:    2,
+			tokens: []string{"é", "ön", "abc"},
+			want:   []string{"ön"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := LengthFilter(tt.min, tt.max)(tt.tokens); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("LengthFilter() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_WithTokenizer(t *testing.T) {
+	i := New(
+		WithTokenizer(WhitespaceTokenizer),
+		WithCharFilters(ReplaceCharFilter("!", "")),
+		WithTokenFilters(LowercaseFilter),
+		WithDocuments([]string{"Great product!", "great service"}),
+	)
+
+	if got, want := i.DocumentsWithTerm("great"), 2; len(got) != want {
+		t.Errorf("len(DocumentsWithTerm()) = %v, want %v", len(got), want)
+	}
+
+	if got := i.Search("GREAT!", 0); len(got) != 2 {
+		t.Errorf("len(Search()) = %v, want %v", len(got), 2)
+	}
+}
+
+func TestTfIdf_WithAnalyzer(t *testing.T) {
+	analyzer := NewAnalyzer(WhitespaceTokenizer)
+	i := New(
+		WithAnalyzer(analyzer),
+		WithDocuments([]string{"Great product!"}),
+	)
+
+	if got, want := i.DocumentsWithTerm("product!"), 1; len(got) != want {
+		t.Errorf("len(DocumentsWithTerm()) = %v, want %v", len(got), want)
+	}
+}
+
+func TestTfIdf_WithAnalyzerShared(t *testing.T) {
+	analyzer := NewAnalyzer(WhitespaceTokenizer)
+	lowercase := New(WithAnalyzer(analyzer), WithTokenFilters(LowercaseFilter), WithTokenizer(UnicodeTokenizer))
+	shared := New(WithAnalyzer(analyzer))
+
+	if got, want := analyzer.Tokenize("Great product!"), []string{"Great", "product!"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("WithTokenizer() and WithTokenFilters() changed the shared analyzer, Tokenize() = %v, want %v", got, want)
+	}
+
+	_ = lowercase.AddDocumentWithID("1", "Great product!")
+	_ = shared.AddDocumentWithID("1", "Great product!")
+	if got := lowercase.DocumentsWithTerm("great"); len(got) != 1 {
+		t.Errorf("DocumentsWithTerm() = %v, want the lowercased term indexed", got)
+	}
+	if got := shared.DocumentsWithTerm("Great"); len(got) != 1 {
+		t.Errorf("DocumentsWithTerm() = %v, want the unchanged term indexed", got)
+	}
+}
+
+func TestTfIdf_WithAnalyzerNil(t *testing.T) {
+	i := New(WithAnalyzer(nil))
+	if err := i.AddDocumentWithID("1", "Great product!"); err != nil {
+		t.Fatalf("AddDocumentWithID() error = %v", err)
+	}
+
+	if got := i.DocumentsWithTerm("great"); len(got) != 1 {
+		t.Errorf("DocumentsWithTerm() = %v, want the default analyzer used", got)
+	}
+}
+
+func TestTfIdf_WithUnicodeTokenizer(t *testing.T) {
+	i := New(
+		WithTokenizer(UnicodeTokenizer),
+		WithDocuments([]string{"Great product!", "great service", "«Great» value"}),
+	)
+
+	if got, want := len(i.DocumentsWithTerm("great")), 3; got != want {
+		t.Errorf("len(DocumentsWithTerm()) = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,34 @@
+package go_tf_idf
+
+import "math"
+
+const (
+	DefaultBM25K1 = 1.2
+	DefaultBM25B  = 0.75
+)
+
+// BM25Scorer returns an Okapi BM25 Scorer. k1 controls term frequency
+// saturation and b the strength of document length normalization.
+func BM25Scorer(k1, b float64) Scorer {
+	return func(stats TermStats) float64 {
+		if stats.TermCount == 0 {
+			return 0
+		}
+
+		n := float64(stats.DocumentCount)
+		df := float64(stats.DocumentFrequency)
+		idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+
+		tf := float64(stats.TermCount)
+		norm := 1 - b
+		if stats.AverageDocumentLength > 0 {
+			norm += b * float64(stats.DocumentLength) / stats.AverageDocumentLength
+		}
+
+		return idf * tf * (k1 + 1) / (tf + k1*norm)
+	}
+}
+
+func WithBM25(k1, b float64) Option {
+	return WithScorer(BM25Scorer(k1, b))
+}
@@ -0,0 +1,100 @@
+package go_tf_idf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestBM25Scorer(t *testing.T) {
+	tests := []struct {
+		name  string
+		k1    float64
+		b     float64
+		stats TermStats
+		want  float64
+	}{
+		{
+			name:  "'example' doc2",
+			k1:    DefaultBM25K1,
+			b:     DefaultBM25B,
+			stats: TermStats{TermCount: 3, DocumentLength: 7, DocumentFrequency: 1, DocumentCount: 2, AverageDocumentLength: 6},
+			want:  1.0516715842978481,
+		},
+		{
+			name:  "no length normalization",
+			k1:    DefaultBM25K1,
+			b:     0,
+			stats: TermStats{TermCount: 3, DocumentLength: 7, DocumentFrequency: 1, DocumentCount: 2, AverageDocumentLength: 6},
+			want:  1.0892312837370568,
+		},
+		{
+			name:  "term not in document",
+			k1:    DefaultBM25K1,
+			b:     DefaultBM25B,
+			stats: TermStats{TermCount: 0, DocumentLength: 5, DocumentFrequency: 1, DocumentCount: 2, AverageDocumentLength: 6},
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := BM25Scorer(tt.k1, tt.b)(tt.stats); got != tt.want {
+				t.Errorf("BM25Scorer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_averageDocumentLength(t *testing.T) {
+	i := New()
+	if got := i.averageDocumentLength(); got != 0 {
+		t.Errorf("averageDocumentLength() = %v, want %v", got, 0)
+	}
+
+	_ = i.AddDocumentWithID("1", doc1Content)
+	_ = i.AddDocumentWithID("2", doc2Content)
+	if got := i.averageDocumentLength(); got != 6 {
+		t.Errorf("averageDocumentLength() = %v, want %v", got, 6)
+	}
+
+	_ = i.UpdateDocument("2", "example")
+	if got := i.averageDocumentLength(); got != 3 {
+		t.Errorf("averageDocumentLength() = %v, want %v", got, 3)
+	}
+
+	_ = i.RemoveDocumentByID("1")
+	if got := i.averageDocumentLength(); got != 1 {
+		t.Errorf("averageDocumentLength() = %v, want %v", got, 1)
+	}
+}
+
+func TestTfIdf_SearchBM25(t *testing.T) {
+	i := New(
+		WithBM25(DefaultBM25K1, DefaultBM25B),
+	)
+	_ = i.AddDocumentWithID("short", "rust compiler")
+	_ = i.AddDocumentWithID("long", "rust compiler errors are reported by the compiler frontend and the backend")
+	_ = i.AddDocumentWithID("other", "go toolchain")
+
+	got := i.Search("rust", 0)
+	if len(got) != 2 || got[0].ID != "short" || got[1].ID != "long" {
+		t.Errorf("Search() = %v, want short before long", got)
+	}
+}
+
+func TestTfIdf_ScoredVectorByID(t *testing.T) {
+	i := New()
+	_ = i.AddDocumentWithID("1", doc1Content)
+	_ = i.AddDocumentWithID("2", doc2Content)
+
+	if got, want := i.ScoredVectorByID("1"), i.TermFrequencyInverseDocumentFrequencyForDocumentByID("1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("ScoredVectorByID() = %v, want %v", got, want)
+	}
+
+	if got, want := i.ScoredVectorByID("3"), make([]float64, 6); !reflect.DeepEqual(got, want) {
+		t.Errorf("ScoredVectorByID() = %v, want %v", got, want)
+	}
+
+	if got, want := i.ScoreForTermByID("example", "2"), 0.12901285528456335; got != want {
+		t.Errorf("ScoreForTermByID() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,123 @@
+package go_tf_idf
+
+import "errors"
+
+var ErrNotFitted = errors.New("corpus statistics are not frozen")
+
+// Fit freezes the vocabulary and corpus statistics of the TfIdf, like fitting
+// scikit-learn's TfidfVectorizer, so that Transform can vectorize new text
+// against them. Adding, updating or removing documents returns ErrFrozen
+// until Unfreeze is called. Fit does nothing if the statistics are already
+// frozen.
+func (i *TfIdf) Fit() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.frozen != nil {
+		return
+	}
+
+	stats := &corpusStats{
+		documentCount:              len(i.Documents),
+		languages:                  make(map[string]int, len(i.index.languages)),
+		documentFrequencies:        make(map[string]int, len(i.termToIndex)),
+		inverseDocumentFrequencies: make(map[string]float64, len(i.termToIndex)),
+		averageDocumentLength:      i.averageDocumentLength(),
+		averageUniqueTerms:         i.averageUniqueTerms(),
+		fitted:                     true,
+	}
+	for lang, count := range i.index.languages {
+		stats.languages[lang] = count
+	}
+	for term := range i.termToIndex {
+		stats.documentFrequencies[term] = i.index.documentFrequency(term)
+		stats.inverseDocumentFrequencies[term] = i.appliedInverseDocumentFrequency(term)
+	}
+
+	i.frozen = stats
+}
+
+// Unfreeze makes the corpus statistics follow the documents of the TfIdf
+// again. A scoring-only TfIdf created by ImportJSON has no documents and
+// loses its vocabulary.
+func (i *TfIdf) Unfreeze() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.frozen == nil {
+		return
+	}
+
+	i.frozen = nil
+	dropped := make([]string, 0)
+	for term := range i.termToIndex {
+		if i.index.documentFrequency(term) == 0 {
+			dropped = append(dropped, term)
+		}
+	}
+	i.dropTerms(dropped)
+}
+
+// Transform returns the vector of text over the frozen vocabulary, analyzed in
+// its detected language and weighted with the frozen corpus statistics like a
+// document of the corpus. Terms
+// outside the vocabulary are ignored, including by the normalization of a
+// Weighting, so that with WithWeighting("nsc") the vector equals the one of
+// scikit-learn's TfidfVectorizer with default settings. Transform returns
+// ErrNotFitted unless the statistics are frozen by Fit or ImportJSON.
+func (i *TfIdf) Transform(text string) ([]float64, error) {
+	doc, err := i.newDocument("", text)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	vector, err := i.transform(doc, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return vector.Dense(len(i.termToIndex)), nil
+}
+
+// TransformSparse is the sparse form of Transform.
+func (i *TfIdf) TransformSparse(text string) (SparseVector, error) {
+	doc, err := i.newDocument("", text)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.transform(doc, err)
+}
+
+// transform weighs doc, created from a text with error err, against the
+// frozen statistics. Text without tokens has a zero vector.
+func (i *TfIdf) transform(doc Document, err error) (SparseVector, error) {
+	if i.frozen == nil {
+		return SparseVector{}, ErrNotFitted
+	}
+
+	if err != nil {
+		return i.sparseVector(nil), nil
+	}
+
+	return i.sparseVector(i.termWeights(i.vocabularyDocument(doc))), nil
+}
+
+// vocabularyDocument returns a copy of doc without the terms outside the
+// vocabulary. Token counts are left unchanged.
+func (i *TfIdf) vocabularyDocument(doc Document) Document {
+	termCount := make(map[string]int, len(doc.TermCount))
+	uniqueTokens := make([]string, 0, len(doc.UniqueTokens))
+	for _, term := range doc.UniqueTokens {
+		if _, ok := i.termToIndex[term]; !ok {
+			continue
+		}
+
+		termCount[term] = doc.TermCount[term]
+		uniqueTokens = append(uniqueTokens, term)
+	}
+
+	doc.TermCount = termCount
+	doc.UniqueTokens = uniqueTokens
+	return doc
+}
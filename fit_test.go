@@ -0,0 +1,133 @@
+package go_tf_idf
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTfIdf_Transform(t *testing.T) {
+	documents := []string{"the cat sat", "the dog sat", "the cat ran"}
+	tests := []struct {
+		name string
+		opts []Option
+		text string
+		want []float64
+	}{
+		{
+			name: "scikit-learn defaults",
+			opts: []Option{WithWeighting("nsc")},
+			text: "the cat cat flew",
+			want: []float64{0.3619650009883935, 0.9321916852554909, 0, 0, 0},
+		},
+		{
+			name: "default weighting",
+			text: "the cat cat flew",
+			want: []float64{0, 0.5 * math.Log10(1.5), 0, 0, 0},
+		},
+		{
+			name: "only unknown terms",
+			opts: []Option{WithWeighting("nsc")},
+			text: "birds flew",
+			want: []float64{0, 0, 0, 0, 0},
+		},
+		{
+			name: "no tokens",
+			text: "",
+			want: []float64{0, 0, 0, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(append(tt.opts, WithDocuments(documents))...)
+			i.Fit()
+
+			got, err := i.Transform(tt.text)
+			if err != nil {
+				t.Fatalf("Transform() error = %v", err)
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Transform() = %v, want %v", got, tt.want)
+			}
+			for index := range got {
+				if math.Abs(got[index]-tt.want[index]) > 1e-12 {
+					t.Errorf("Transform() = %v, want %v", got, tt.want)
+					break
+				}
+			}
+
+			sparse, err := i.TransformSparse(tt.text)
+			if err != nil {
+				t.Fatalf("TransformSparse() error = %v", err)
+			}
+			if !reflect.DeepEqual(sparse.Dense(len(got)), got) {
+				t.Errorf("TransformSparse() = %v, want %v", sparse, got)
+			}
+		})
+	}
+}
+
+func TestTfIdf_Fit(t *testing.T) {
+	i := New(WithDocuments([]string{"the cat sat", "the dog sat"}))
+	if _, err := i.Transform("the cat"); err != ErrNotFitted {
+		t.Fatalf("Transform() error = %v, want %v", err, ErrNotFitted)
+	}
+
+	i.Fit()
+	want := i.TermFrequencyInverseDocumentFrequencyForDocument("the cat sat")
+	if _, err := i.Transform("a new cat"); err != nil {
+		t.Fatalf("Transform() error = %v", err)
+	}
+	if err := i.AddDocumentWithID("new", "a new cat"); err != ErrFrozen {
+		t.Errorf("AddDocumentWithID() error = %v, want %v", err, ErrFrozen)
+	}
+	if got := i.TermFrequencyInverseDocumentFrequencyForDocument("the cat sat"); !reflect.DeepEqual(got, want) {
+		t.Errorf("Transform() changed document vector to %v, want %v", got, want)
+	}
+
+	i.Unfreeze()
+	if err := i.AddDocumentWithID("new", "a new cat"); err != nil {
+		t.Errorf("AddDocumentWithID() after Unfreeze() error = %v", err)
+	}
+	if _, err := i.Transform("the cat"); err != ErrNotFitted {
+		t.Errorf("Transform() after Unfreeze() error = %v, want %v", err, ErrNotFitted)
+	}
+}
+
+func TestTfIdf_TransformWithLanguageDetection(t *testing.T) {
+	german := "Ich habe mein Passwort vergessen und kann meine Bestellungen nicht sehen."
+	i := New(WithLanguageDetection(), WithDocuments([]string{
+		"She was running through the parks near the river every morning before work.",
+		"The new system reports every error to the team that maintains the service.",
+		"Please send the invoice for the last order to our accounting department.",
+		german,
+	}))
+	i.Fit()
+
+	got, err := i.TransformSparse(german)
+	if err != nil {
+		t.Fatalf("TransformSparse() error = %v", err)
+	}
+
+	if want := i.SparseVectorForDocument(german); !reflect.DeepEqual(got, want) {
+		t.Errorf("TransformSparse() = %v, want %v", got, want)
+	}
+}
+
+func TestTfIdf_Unfreeze(t *testing.T) {
+	i, err := ImportJSON(strings.NewReader(`{"version": 1, "document_count": 1, "terms": [{"term": "a", "index": 0, "document_frequency": 1}]}`))
+	if err != nil {
+		t.Fatalf("ImportJSON() error = %v", err)
+	}
+
+	if got, _ := i.Transform("a b"); len(got) != 1 {
+		t.Errorf("Transform() = %v, want a vector of length 1", got)
+	}
+
+	i.Unfreeze()
+	if got := i.TermFrequencyInverseDocumentFrequencyForDocument("a"); len(got) != 0 {
+		t.Errorf("Unfreeze() kept vocabulary, vector = %v", got)
+	}
+}
@@ -0,0 +1,108 @@
+package go_tf_idf
+
+import "sort"
+
+type Posting struct {
+	DocumentID string
+	Frequency  int
+	Positions  []int
+}
+
+// invertedIndex maps every term in the corpus to the documents containing it.
+// The number of postings of a term is its document frequency. totalLength and
+// totalUnique are the summed token and unique term counts of all indexed
+// documents, and languages counts their detected languages.
+type invertedIndex struct {
+	postings    map[string]map[string]Posting
+	totalLength int
+	totalUnique int
+	languages   map[string]int
+}
+
+func newInvertedIndex() *invertedIndex {
+	return &invertedIndex{
+		postings:  make(map[string]map[string]Posting, 0),
+		languages: make(map[string]int, 0),
+	}
+}
+
+func (x *invertedIndex) countLanguage(lang string, delta int) {
+	x.languages[lang] += delta
+	if x.languages[lang] == 0 {
+		delete(x.languages, lang)
+	}
+}
+
+func (x *invertedIndex) add(doc Document) {
+	for _, term := range doc.UniqueTokens {
+		if _, ok := x.postings[term]; !ok {
+			x.postings[term] = make(map[string]Posting, 0)
+		}
+
+		x.postings[term][doc.ID] = Posting{
+			DocumentID: doc.ID,
+			Frequency:  doc.TermCount[term],
+			Positions:  doc.positions[term],
+		}
+	}
+}
+
+// remove deletes the postings of document id for the given terms and returns
+// the terms no longer contained in any document.
+func (x *invertedIndex) remove(id string, terms []string) []string {
+	dropped := make([]string, 0)
+	for _, term := range terms {
+		delete(x.postings[term], id)
+
+		if len(x.postings[term]) == 0 {
+			delete(x.postings, term)
+			dropped = append(dropped, term)
+		}
+	}
+
+	return dropped
+}
+
+func (x *invertedIndex) documentFrequency(term string) int {
+	return len(x.postings[term])
+}
+
+// list returns the postings of term ordered by document ID.
+func (x *invertedIndex) list(term string) []Posting {
+	postings := make([]Posting, 0, len(x.postings[term]))
+	for _, posting := range x.postings[term] {
+		postings = append(postings, posting)
+	}
+
+	sort.Slice(postings, func(a, b int) bool {
+		return postings[a].DocumentID < postings[b].DocumentID
+	})
+
+	return postings
+}
+
+func WithPositions() Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.recordPositions = true
+	}
+}
+
+func (i *TfIdf) Postings(term string) []Posting {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.index.list(term)
+}
+
+func (i *TfIdf) DocumentsWithTerm(term string) []string {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	postings := i.index.list(term)
+	ids := make([]string, len(postings))
+	for index, posting := range postings {
+		ids[index] = posting.DocumentID
+	}
+
+	return ids
+}
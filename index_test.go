@@ -0,0 +1,80 @@
+package go_tf_idf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTfIdf_Postings(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		term    string
+		want    []Posting
+	}{
+		{
+			name: "term in both documents",
+			term: "this",
+			want: []Posting{
+				{DocumentID: "1", Frequency: 1},
+				{DocumentID: "2", Frequency: 1},
+			},
+		},
+		{
+			name: "term in one document",
+			term: "example",
+			want: []Posting{
+				{DocumentID: "2", Frequency: 3},
+			},
+		},
+		{
+			name:    "with positions",
+			options: []Option{WithPositions()},
+			term:    "a",
+			want: []Posting{
+				{DocumentID: "1", Frequency: 2, Positions: []int{2, 3}},
+			},
+		},
+		{
+			name: "unknown term",
+			term: "asdf",
+			want: []Posting{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(tt.options...)
+			_ = i.AddDocumentWithID("1", doc1Content)
+			_ = i.AddDocumentWithID("2", doc2Content)
+
+			if got := i.Postings(tt.term); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Postings() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_DocumentsWithTerm(t *testing.T) {
+	i := New()
+	_ = i.AddDocumentWithID("1", doc1Content)
+	_ = i.AddDocumentWithID("2", doc2Content)
+	_ = i.AddDocumentWithID("3", "another sample")
+
+	if got, want := i.DocumentsWithTerm("sample"), []string{"1", "3"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DocumentsWithTerm() = %v, want %v", got, want)
+	}
+
+	_ = i.UpdateDocument("3", "another")
+	if got, want := i.DocumentsWithTerm("sample"), []string{"1"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DocumentsWithTerm() = %v, want %v", got, want)
+	}
+
+	_ = i.RemoveDocumentByID("1")
+	if got, want := i.DocumentsWithTerm("sample"), []string{}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DocumentsWithTerm() = %v, want %v", got, want)
+	}
+
+	if _, ok := i.index.postings["sample"]; ok {
+		t.Errorf("postings for dropped term still present")
+	}
+}
@@ -0,0 +1,441 @@
+package go_tf_idf
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"io/ioutil"
+	"os"
+	"path"
+	"strings"
+)
+
+// DefaultMaxRecordSize is the default limit on the size of a line read by
+// IngestLines and IngestJSONLines and of a file read by IngestFS.
+const DefaultMaxRecordSize = 1 << 20
+
+var ErrInvalidRecord = errors.New("invalid record")
+
+// errIngestStopped stops the directory walk of an ingestion that ended early.
+var errIngestStopped = errors.New("ingestion stopped")
+
+// IngestProgress reports the progress of an ingestion. Records counts the
+// records read, Documents those added and Skipped those that were empty,
+// already contained in the corpus or invalid. Bytes counts the bytes read
+// from the source.
+type IngestProgress struct {
+	Records   int
+	Documents int
+	Skipped   int
+	Bytes     int64
+}
+
+type IngestOption func(config *ingestConfig)
+
+type ingestConfig struct {
+	progress       func(IngestProgress)
+	progressEvery  int
+	skipInvalid    bool
+	maxRecordSize  int
+	idField        string
+	textField      string
+	idColumn       string
+	textColumns    []string
+	comma          rune
+	extensions     []string
+	bytesRead      *int64
+	recordLocation func() string
+}
+
+func defaultIngestConfig() ingestConfig {
+	return ingestConfig{
+		maxRecordSize: DefaultMaxRecordSize,
+		idField:       "id",
+		textField:     "text",
+		idColumn:      "id",
+		textColumns:   []string{"text"},
+		comma:         ',',
+		extensions:    []string{".txt"},
+	}
+}
+
+// WithProgress calls progress after every `every` records and once when the
+// ingestion ends.
+func WithProgress(progress func(IngestProgress), every int) IngestOption {
+	return func(config *ingestConfig) {
+		config.progress = progress
+		config.progressEvery = every
+	}
+}
+
+// WithSkipInvalidRecords counts invalid records as skipped instead of ending
+// the ingestion with ErrInvalidRecord.
+func WithSkipInvalidRecords() IngestOption {
+	return func(config *ingestConfig) {
+		config.skipInvalid = true
+	}
+}
+
+// WithMaxRecordSize limits the size of a line read by IngestLines and
+// IngestJSONLines and of a file read by IngestFS. Longer lines end the
+// ingestion with bufio.ErrTooLong, larger files are invalid records. A size
+// that is not positive is ignored.
+func WithMaxRecordSize(size int) IngestOption {
+	return func(config *ingestConfig) {
+		if size > 0 {
+			config.maxRecordSize = size
+		}
+	}
+}
+
+// WithJSONFields sets the fields IngestJSONLines reads the ID and text of a
+// document from. They default to "id" and "text". Documents without ID are
+// keyed like AddDocument.
+func WithJSONFields(idField, textField string) IngestOption {
+	return func(config *ingestConfig) {
+		config.idField = idField
+		config.textField = textField
+	}
+}
+
+// WithCSVColumns sets the header columns IngestCSV reads the ID and text of a
+// document from. The values of multiple text columns are joined by a space.
+// They default to "id" and "text". If the ID column is empty or missing,
+// documents are keyed like AddDocument.
+func WithCSVColumns(idColumn string, textColumns ...string) IngestOption {
+	return func(config *ingestConfig) {
+		config.idColumn = idColumn
+		config.textColumns = textColumns
+	}
+}
+
+// WithCSVComma sets the field delimiter of IngestCSV, which defaults to ','.
+func WithCSVComma(comma rune) IngestOption {
+	return func(config *ingestConfig) {
+		config.comma = comma
+	}
+}
+
+// WithExtensions sets the extensions of the files read by IngestFS and
+// IngestDir, which default to ".txt". Without extensions, all files are read.
+func WithExtensions(extensions ...string) IngestOption {
+	return func(config *ingestConfig) {
+		config.extensions = extensions
+	}
+}
+
+// IngestLines adds every line read from r as a document keyed like
+// AddDocument. Empty lines are skipped.
+func (i *TfIdf) IngestLines(r io.Reader, opts ...IngestOption) error {
+	config := newIngestConfig(opts)
+	scanner, line := newLineScanner(&countingReader{r: r, n: config.bytesRead}, config)
+	config.recordLocation = func() string { return fmt.Sprintf("line %d", *line) }
+
+	return i.ingest(config, func() (string, string, error) {
+		if !scanner.Scan() {
+			return "", "", scannerErr(scanner)
+		}
+
+		return "", scanner.Text(), nil
+	})
+}
+
+// IngestJSONLines adds a document for every JSON object read from r, one per
+// line, taking its ID and text from the fields set by WithJSONFields. IDs may
+// be strings or numbers. Blank lines are skipped.
+func (i *TfIdf) IngestJSONLines(r io.Reader, opts ...IngestOption) error {
+	config := newIngestConfig(opts)
+	scanner, line := newLineScanner(&countingReader{r: r, n: config.bytesRead}, config)
+	config.recordLocation = func() string { return fmt.Sprintf("line %d", *line) }
+
+	return i.ingest(config, func() (string, string, error) {
+		for {
+			if !scanner.Scan() {
+				return "", "", scannerErr(scanner)
+			}
+
+			if len(strings.TrimSpace(scanner.Text())) > 0 {
+				break
+			}
+		}
+
+		record := make(map[string]interface{}, 0)
+		decoder := json.NewDecoder(strings.NewReader(scanner.Text()))
+		decoder.UseNumber()
+		if err := decoder.Decode(&record); err != nil {
+			return "", "", fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+		}
+
+		text, ok := record[config.textField].(string)
+		if !ok {
+			return "", "", fmt.Errorf("%w: missing string field %q", ErrInvalidRecord, config.textField)
+		}
+
+		switch id := record[config.idField].(type) {
+		case nil:
+			return "", text, nil
+		case string:
+			return id, text, nil
+		case json.Number:
+			return id.String(), text, nil
+		}
+
+		return "", "", fmt.Errorf("%w: field %q is neither a string nor a number", ErrInvalidRecord, config.idField)
+	})
+}
+
+// IngestCSV adds a document for every row read from r, taking its ID and text
+// from the columns set by WithCSVColumns. The first row must be a header
+// naming the columns.
+func (i *TfIdf) IngestCSV(r io.Reader, opts ...IngestOption) error {
+	config := newIngestConfig(opts)
+	reader := csv.NewReader(&countingReader{r: r, n: config.bytesRead})
+	reader.Comma = config.comma
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return fmt.Errorf("%w: header: %v", ErrInvalidRecord, err)
+	}
+
+	idColumn := -1
+	textColumns := make([]int, len(config.textColumns))
+	for k, name := range config.textColumns {
+		textColumns[k] = -1
+		for column, field := range header {
+			if field == name {
+				textColumns[k] = column
+			}
+		}
+
+		if textColumns[k] < 0 {
+			return fmt.Errorf("%w: header misses text column %q", ErrInvalidRecord, name)
+		}
+	}
+	for column, field := range header {
+		if config.idColumn != "" && field == config.idColumn {
+			idColumn = column
+		}
+	}
+
+	row := 1
+	config.recordLocation = func() string { return fmt.Sprintf("row %d", row) }
+	return i.ingest(config, func() (string, string, error) {
+		record, err := reader.Read()
+		row++
+		if err == io.EOF {
+			return "", "", err
+		}
+		if err != nil {
+			var parseErr *csv.ParseError
+			if errors.As(err, &parseErr) {
+				return "", "", fmt.Errorf("%w: %v", ErrInvalidRecord, err)
+			}
+			return "", "", err
+		}
+
+		texts := make([]string, len(textColumns))
+		for k, column := range textColumns {
+			texts[k] = record[column]
+		}
+
+		id := ""
+		if idColumn >= 0 {
+			id = record[idColumn]
+		}
+
+		return id, strings.Join(texts, " "), nil
+	})
+}
+
+// IngestFS walks fsys in lexical order and adds every file with one of the
+// extensions set by WithExtensions as a document, keyed by its path. Files
+// larger than the size set by WithMaxRecordSize are invalid records.
+func (i *TfIdf) IngestFS(fsys fs.FS, opts ...IngestOption) error {
+	config := newIngestConfig(opts)
+
+	paths := make(chan string)
+	walkErr := make(chan error, 1)
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		defer close(paths)
+		walkErr <- fs.WalkDir(fsys, ".", func(name string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+
+			if entry.IsDir() || !hasExtension(name, config.extensions) {
+				return nil
+			}
+
+			select {
+			case paths <- name:
+				return nil
+			case <-done:
+				return errIngestStopped
+			}
+		})
+	}()
+
+	current := ""
+	config.recordLocation = func() string { return current }
+	return i.ingest(config, func() (string, string, error) {
+		name, ok := <-paths
+		if !ok {
+			if err := <-walkErr; err != nil {
+				return "", "", err
+			}
+			return "", "", io.EOF
+		}
+
+		current = name
+		data, err := readFile(fsys, name, config)
+		if err != nil {
+			return "", "", err
+		}
+
+		return name, string(data), nil
+	})
+}
+
+// readFile reads the file name of fsys, reading at most one byte more than
+// the maximum record size so that large files are never held in memory.
+func readFile(fsys fs.FS, name string, config ingestConfig) ([]byte, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := &countingReader{r: f, n: config.bytesRead}
+	data, err := ioutil.ReadAll(io.LimitReader(r, int64(config.maxRecordSize)+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) > config.maxRecordSize {
+		return nil, fmt.Errorf("%w: file exceeds %d bytes", ErrInvalidRecord, config.maxRecordSize)
+	}
+
+	return data, nil
+}
+
+// IngestDir is IngestFS for the directory tree at root.
+func (i *TfIdf) IngestDir(root string, opts ...IngestOption) error {
+	return i.IngestFS(os.DirFS(root), opts...)
+}
+
+// ingest adds the documents returned by next until it returns io.EOF. A
+// document without ID is keyed like AddDocument.
+func (i *TfIdf) ingest(config ingestConfig, next func() (id string, text string, err error)) error {
+	progress := IngestProgress{}
+	report := func() {
+		if config.progress != nil {
+			progress.Bytes = *config.bytesRead
+			config.progress(progress)
+		}
+	}
+	defer report()
+
+	for {
+		id, text, err := next()
+		if err == io.EOF {
+			return nil
+		}
+
+		progress.Records++
+		switch {
+		case errors.Is(err, ErrInvalidRecord) && config.skipInvalid:
+			progress.Skipped++
+		case err != nil:
+			return fmt.Errorf("%s: %w", config.recordLocation(), err)
+		default:
+			if id == "" {
+				id = md5Hash(text)
+			}
+
+			switch err := i.AddDocumentWithID(id, text); err {
+			case nil:
+				progress.Documents++
+			case ErrDocumentExists, ErrEmptyDocument:
+				progress.Skipped++
+			default:
+				return err
+			}
+		}
+
+		if config.progressEvery > 0 && progress.Records%config.progressEvery == 0 {
+			report()
+		}
+	}
+}
+
+func newIngestConfig(opts []IngestOption) ingestConfig {
+	config := defaultIngestConfig()
+	for _, opt := range opts {
+		opt(&config)
+	}
+	config.bytesRead = new(int64)
+
+	return config
+}
+
+// newLineScanner returns a scanner over the lines of r and the number of the
+// line it last scanned.
+func newLineScanner(r io.Reader, config ingestConfig) (*bufio.Scanner, *int) {
+	line := 0
+	scanner := bufio.NewScanner(r)
+	size := 4096
+	if config.maxRecordSize < size {
+		size = config.maxRecordSize
+	}
+	scanner.Buffer(make([]byte, 0, size), config.maxRecordSize)
+	scanner.Split(func(data []byte, atEOF bool) (int, []byte, error) {
+		advance, token, err := bufio.ScanLines(data, atEOF)
+		if token != nil {
+			line++
+		}
+		return advance, token, err
+	})
+
+	return scanner, &line
+}
+
+func scannerErr(scanner *bufio.Scanner) error {
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	return io.EOF
+}
+
+func hasExtension(name string, extensions []string) bool {
+	if len(extensions) == 0 {
+		return true
+	}
+
+	for _, extension := range extensions {
+		if path.Ext(name) == extension {
+			return true
+		}
+	}
+
+	return false
+}
+
+// countingReader counts the bytes read from r into n.
+type countingReader struct {
+	r io.Reader
+	n *int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	*c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,280 @@
+package go_tf_idf
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func documentIDs(i *TfIdf) []string {
+	ids := make([]string, 0, len(i.Documents))
+	for id := range i.Documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	return ids
+}
+
+func TestTfIdf_Ingest(t *testing.T) {
+	lines := func(i *TfIdf, r io.Reader, opts ...IngestOption) error { return i.IngestLines(r, opts...) }
+	jsonLines := func(i *TfIdf, r io.Reader, opts ...IngestOption) error { return i.IngestJSONLines(r, opts...) }
+	csv := func(i *TfIdf, r io.Reader, opts ...IngestOption) error { return i.IngestCSV(r, opts...) }
+
+	tests := []struct {
+		name         string
+		ingest       func(i *TfIdf, r io.Reader, opts ...IngestOption) error
+		input        string
+		opts         []IngestOption
+		wantIDs      []string
+		wantProgress IngestProgress
+		wantErr      error
+	}{
+		{
+			name:         "lines",
+			ingest:       lines,
+			input:        "the cat\n\nthe dog\r\nthe cat\nthe bird",
+			wantIDs:      []string{md5Hash("the cat"), md5Hash("the dog"), md5Hash("the bird")},
+			wantProgress: IngestProgress{Records: 5, Documents: 3, Skipped: 2},
+		},
+		{
+			name:    "lines too long",
+			ingest:  lines,
+			input:   "the cat\nthe dog",
+			opts:    []IngestOption{WithMaxRecordSize(4)},
+			wantIDs: []string{},
+			wantErr: bufio.ErrTooLong,
+		},
+		{
+			name:         "negative max record size",
+			ingest:       lines,
+			input:        "the cat\nthe dog",
+			opts:         []IngestOption{WithMaxRecordSize(-1)},
+			wantIDs:      []string{md5Hash("the cat"), md5Hash("the dog")},
+			wantProgress: IngestProgress{Records: 2, Documents: 2},
+		},
+		{
+			name:         "zero max record size",
+			ingest:       jsonLines,
+			input:        `{"id": "a", "text": "the cat"}`,
+			opts:         []IngestOption{WithMaxRecordSize(0)},
+			wantIDs:      []string{"a"},
+			wantProgress: IngestProgress{Records: 1, Documents: 1},
+		},
+		{
+			name:   "json lines",
+			ingest: jsonLines,
+			input: `{"id": "a", "text": "the cat"}
+{"id": 7, "text": "the dog"}
+
+{"text": "the bird"}`,
+			wantIDs:      []string{"a", "7", md5Hash("the bird")},
+			wantProgress: IngestProgress{Records: 3, Documents: 3},
+		},
+		{
+			name:         "json lines with fields",
+			ingest:       jsonLines,
+			input:        `{"key": "a", "body": "the cat", "id": "ignored"}`,
+			opts:         []IngestOption{WithJSONFields("key", "body")},
+			wantIDs:      []string{"a"},
+			wantProgress: IngestProgress{Records: 1, Documents: 1},
+		},
+		{
+			name:         "invalid json line",
+			ingest:       jsonLines,
+			input:        "{\"id\": \"a\", \"text\": \"the cat\"}\n{\"id\": \"b\"}\n{\"id\": \"c\", \"text\": \"the dog\"}",
+			wantIDs:      []string{"a"},
+			wantProgress: IngestProgress{Records: 2, Documents: 1},
+			wantErr:      ErrInvalidRecord,
+		},
+		{
+			name:         "skipped invalid json line",
+			ingest:       jsonLines,
+			input:        "{\"id\": \"a\", \"text\": \"the cat\"}\nnot json\n{\"id\": \"c\", \"text\": \"the dog\"}",
+			opts:         []IngestOption{WithSkipInvalidRecords()},
+			wantIDs:      []string{"a", "c"},
+			wantProgress: IngestProgress{Records: 3, Documents: 2, Skipped: 1},
+		},
+		{
+			name:         "csv",
+			ingest:       csv,
+			input:        "id,text\na,the cat\nb,\"the dog, again\"\n",
+			wantIDs:      []string{"a", "b"},
+			wantProgress: IngestProgress{Records: 2, Documents: 2},
+		},
+		{
+			name:         "csv with columns",
+			ingest:       csv,
+			input:        "key;title;body\na;the;cat\nb;the;dog\n",
+			opts:         []IngestOption{WithCSVColumns("key", "title", "body"), WithCSVComma(';')},
+			wantIDs:      []string{"a", "b"},
+			wantProgress: IngestProgress{Records: 2, Documents: 2},
+		},
+		{
+			name:         "csv without id column",
+			ingest:       csv,
+			input:        "text\nthe cat\n",
+			wantIDs:      []string{md5Hash("the cat")},
+			wantProgress: IngestProgress{Records: 1, Documents: 1},
+		},
+		{
+			name:    "csv missing text column",
+			ingest:  csv,
+			input:   "id,body\na,the cat\n",
+			wantIDs: []string{},
+			wantErr: ErrInvalidRecord,
+		},
+		{
+			name:         "csv with wrong field count",
+			ingest:       csv,
+			input:        "id,text\na,the cat,extra\nb,the dog\n",
+			opts:         []IngestOption{WithSkipInvalidRecords()},
+			wantIDs:      []string{"b"},
+			wantProgress: IngestProgress{Records: 2, Documents: 1, Skipped: 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New()
+			reports := make([]IngestProgress, 0)
+			opts := append(tt.opts, WithProgress(func(progress IngestProgress) {
+				reports = append(reports, progress)
+			}, 0))
+
+			err := tt.ingest(i, strings.NewReader(tt.input), opts...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ingest error = %v, want %v", err, tt.wantErr)
+			}
+
+			sort.Strings(tt.wantIDs)
+			if got := documentIDs(i); !reflect.DeepEqual(got, tt.wantIDs) {
+				t.Errorf("ingest documents = %v, want %v", got, tt.wantIDs)
+			}
+
+			if tt.wantProgress == (IngestProgress{}) {
+				return
+			}
+
+			tt.wantProgress.Bytes = int64(len(tt.input))
+			if !reflect.DeepEqual(reports, []IngestProgress{tt.wantProgress}) {
+				t.Errorf("ingest progress = %v, want %v", reports, tt.wantProgress)
+			}
+		})
+	}
+}
+
+func TestTfIdf_IngestFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"a.txt":         {Data: []byte("the cat")},
+		"notes.md":      {Data: []byte("the notes")},
+		"sub/b.txt":     {Data: []byte("the dog")},
+		"sub/empty.txt": {Data: []byte("")},
+	}
+	tests := []struct {
+		name         string
+		opts         []IngestOption
+		wantIDs      []string
+		wantProgress IngestProgress
+		wantErr      error
+	}{
+		{
+			name:         "text files",
+			wantIDs:      []string{"a.txt", "sub/b.txt"},
+			wantProgress: IngestProgress{Records: 3, Documents: 2, Skipped: 1, Bytes: 14},
+		},
+		{
+			name:         "extensions",
+			opts:         []IngestOption{WithExtensions(".md")},
+			wantIDs:      []string{"notes.md"},
+			wantProgress: IngestProgress{Records: 1, Documents: 1, Bytes: 9},
+		},
+		{
+			name:         "all files",
+			opts:         []IngestOption{WithExtensions()},
+			wantIDs:      []string{"a.txt", "notes.md", "sub/b.txt"},
+			wantProgress: IngestProgress{Records: 4, Documents: 3, Skipped: 1, Bytes: 23},
+		},
+		{
+			name:         "file too large",
+			opts:         []IngestOption{WithMaxRecordSize(6)},
+			wantIDs:      []string{},
+			wantProgress: IngestProgress{Records: 1, Bytes: 7},
+			wantErr:      ErrInvalidRecord,
+		},
+		{
+			name:         "skipped file too large",
+			opts:         []IngestOption{WithMaxRecordSize(6), WithSkipInvalidRecords()},
+			wantIDs:      []string{},
+			wantProgress: IngestProgress{Records: 3, Skipped: 3, Bytes: 14},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New()
+			var got IngestProgress
+			opts := append(tt.opts, WithProgress(func(progress IngestProgress) {
+				got = progress
+			}, 0))
+
+			if err := i.IngestFS(fsys, opts...); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("IngestFS() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if ids := documentIDs(i); !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Errorf("IngestFS() documents = %v, want %v", ids, tt.wantIDs)
+			}
+			if got != tt.wantProgress {
+				t.Errorf("IngestFS() progress = %v, want %v", got, tt.wantProgress)
+			}
+		})
+	}
+}
+
+func TestTfIdf_IngestProgress(t *testing.T) {
+	i := New()
+	records := make([]int, 0)
+	err := i.IngestLines(strings.NewReader("a\nb\nc\nd\ne"), WithProgress(func(progress IngestProgress) {
+		records = append(records, progress.Records)
+	}, 2))
+	if err != nil {
+		t.Fatalf("IngestLines() error = %v", err)
+	}
+
+	if want := []int{2, 4, 5}; !reflect.DeepEqual(records, want) {
+		t.Errorf("IngestLines() reported records %v, want %v", records, want)
+	}
+}
+
+func TestTfIdf_IngestDir(t *testing.T) {
+	root := t.TempDir()
+	if err := os.Mkdir(filepath.Join(root, "sub"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	for name, content := range map[string]string{"a.txt": "the cat", "sub/b.txt": "the dog"} {
+		if err := ioutil.WriteFile(filepath.Join(root, filepath.FromSlash(name)), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	i := New()
+	if err := i.IngestDir(root); err != nil {
+		t.Fatalf("IngestDir() error = %v", err)
+	}
+
+	if got, want := documentIDs(i), []string{"a.txt", "sub/b.txt"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("IngestDir() documents = %v, want %v", got, want)
+	}
+
+	i.Fit()
+	if err := i.IngestDir(root); err != ErrFrozen {
+		t.Errorf("IngestDir() error = %v, want %v", err, ErrFrozen)
+	}
+}
@@ -0,0 +1,243 @@
+package go_tf_idf
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LanguageProfileSize is the number of most frequent n-grams kept in a
+// LanguageProfile.
+const LanguageProfileSize = 1000
+
+// LanguageDetector returns the ISO 639-1 code of the language text is written
+// in, or "" if it cannot tell.
+type LanguageDetector func(text string) string
+
+// LanguageProfile maps the most frequent character n-grams of a text to their
+// rank, 0 being the most frequent, as described by Cavnar and Trenkle in
+// "N-Gram-Based Text Categorization".
+type LanguageProfile map[string]int
+
+// LanguageProfiles holds the built-in profiles used by DetectLanguage, keyed
+// by ISO 639-1 language code. The profiles are built from the stop word list
+// and a sample text of each language.
+var LanguageProfiles = map[string]LanguageProfile{}
+
+func init() {
+	for lang, sample := range languageSamples {
+		words := make([]string, 0, len(StopWordLists[lang]))
+		for word := range StopWordLists[lang] {
+			words = append(words, word)
+		}
+
+		LanguageProfiles[lang] = NewLanguageProfile(sample + " " + strings.Join(words, " "))
+	}
+}
+
+// NewLanguageProfile ranks the character n-grams of length one to three of
+// the words in text. Words are padded with an underscore so that n-grams at
+// word boundaries are told apart from n-grams inside words.
+func NewLanguageProfile(text string) LanguageProfile {
+	counts := make(map[string]int, 0)
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+	for _, word := range words {
+		runes := []rune("_" + word + "_")
+		for n := 1; n <= 3; n++ {
+			for start := 0; start+n <= len(runes); start++ {
+				gram := string(runes[start : start+n])
+				if gram != "_" {
+					counts[gram]++
+				}
+			}
+		}
+	}
+
+	grams := make([]string, 0, len(counts))
+	for gram := range counts {
+		grams = append(grams, gram)
+	}
+
+	sort.Slice(grams, func(a, b int) bool {
+		if counts[grams[a]] != counts[grams[b]] {
+			return counts[grams[a]] > counts[grams[b]]
+		}
+		return grams[a] < grams[b]
+	})
+
+	if len(grams) > LanguageProfileSize {
+		grams = grams[:LanguageProfileSize]
+	}
+
+	profile := make(LanguageProfile, len(grams))
+	for rank, gram := range grams {
+		profile[gram] = rank
+	}
+
+	return profile
+}
+
+// Distance returns the out-of-place measure between the profile of a text and
+// a language profile: the sum of the rank differences of the n-grams of p,
+// with n-grams missing from language counting LanguageProfileSize.
+func (p LanguageProfile) Distance(language LanguageProfile) int {
+	distance := 0
+	for gram, rank := range p {
+		other, ok := language[gram]
+		if !ok {
+			distance += LanguageProfileSize
+			continue
+		}
+
+		if rank > other {
+			distance += rank - other
+		} else {
+			distance += other - rank
+		}
+	}
+
+	return distance
+}
+
+// NewLanguageDetector returns a LanguageDetector choosing the language of the
+// closest of profiles.
+func NewLanguageDetector(profiles map[string]LanguageProfile) LanguageDetector {
+	languages := make([]string, 0, len(profiles))
+	for lang := range profiles {
+		languages = append(languages, lang)
+	}
+	sort.Strings(languages)
+
+	return func(text string) string {
+		profile := NewLanguageProfile(text)
+		if len(profile) == 0 {
+			return ""
+		}
+
+		best, bestDistance := "", 0
+		for _, lang := range languages {
+			if distance := profile.Distance(profiles[lang]); best == "" || distance < bestDistance {
+				best, bestDistance = lang, distance
+			}
+		}
+
+		return best
+	}
+}
+
+// DetectLanguage detects the language of text using LanguageProfiles.
+func DetectLanguage(text string) string {
+	return NewLanguageDetector(LanguageProfiles)(text)
+}
+
+// WithLanguageDetection detects the language of every document with
+// DetectLanguage. See WithLanguageDetector.
+func WithLanguageDetection() Option {
+	return WithLanguageDetector(DetectLanguage)
+}
+
+// WithLanguageDetector detects the language of every document with detector,
+// records it as Document.Language and removes the stop words in StopWordLists
+// and applies the stemmer in Stemmers for that language, in addition to the
+// configured stop words. The configured stemmer is used for languages without
+// a stemmer. Detection often errs on short queries, so a query is analyzed in
+// its detected language only if some document is in it, and otherwise in the
+// language most documents are in.
+func WithLanguageDetector(detector LanguageDetector) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.detector = detector
+	}
+}
+
+var languageSamples = map[string]string{
+	"da": `Alle mennesker er født frie og lige i værdighed og rettigheder. De er
+	udstyret med fornuft og samvittighed, og de bør handle mod hverandre i en
+	broderskabets ånd.
+	Enhver har ret til liv, frihed og personlig sikkerhed. Vi har modtaget
+	din besked og vender tilbage så hurtigt som muligt. Tak for din
+	henvendelse, kunden kan finde flere oplysninger på vores hjemmeside.
+	Hvad tid kommer toget i morgen? Jeg har glemt min adgangskode og kan
+	ikke se mine ordrer.`,
+	"de": `Alle Menschen sind frei und gleich an Würde und Rechten geboren. Sie
+	sind mit Vernunft und Gewissen begabt und sollen einander im Geist der
+	Brüderlichkeit begegnen.
+	Jeder hat das Recht auf Leben, Freiheit und Sicherheit der Person. Wir
+	haben Ihre Nachricht erhalten und melden uns so schnell wie möglich. Vielen
+	Dank für Ihre Anfrage, weitere Informationen finden Sie auf unserer
+	Webseite.
+	Wann kommt der Zug morgen an? Ich habe mein Passwort vergessen und kann
+	meine Bestellungen nicht sehen.`,
+	"en": `All human beings are born free and equal in dignity and rights. They
+	are endowed with reason and conscience and should act towards one another
+	in a spirit of brotherhood.
+	Everyone has the right to life, liberty and security of person. We
+	have received your message and will get back to you as soon as possible.
+	Thank you for your request, you can find more information on our
+	website.
+	When does the train arrive tomorrow? I forgot my password and cannot
+	see my orders.`,
+	"es": `Todos los seres humanos nacen libres e iguales en dignidad y derechos
+	y, dotados como están de razón y conciencia, deben comportarse
+	fraternalmente los unos con los otros.
+	Todo individuo tiene derecho a la vida, a la libertad y a la seguridad
+	de su persona. Hemos recibido su mensaje y le responderemos lo antes
+	posible. Gracias por su consulta, puede encontrar más información en
+	nuestra página web.
+	¿A qué hora llega el tren mañana? He olvidado mi contraseña y no puedo
+	ver mis pedidos todavía.`,
+	"fr": `Tous les êtres humains naissent libres et égaux en dignité et en
+	droits. Ils sont doués de raison et de conscience et doivent agir les uns
+	envers les autres dans un esprit de fraternité.
+	Tout individu a droit à la vie, à la liberté et à la sûreté de sa
+	personne. Nous avons bien reçu votre message et nous vous répondrons dans
+	les plus brefs délais. Merci pour votre demande, vous trouverez plus
+	d'informations sur notre site.
+	À quelle heure arrive le train demain? J'ai oublié mon mot de passe et
+	je ne peux pas voir mes commandes.`,
+	"it": `Tutti gli esseri umani nascono liberi ed eguali in dignità e diritti.
+	Essi sono dotati di ragione e di coscienza e devono agire gli uni verso gli
+	altri in spirito di fratellanza.
+	Ogni individuo ha diritto alla vita, alla libertà ed alla sicurezza
+	della propria persona. Abbiamo ricevuto il suo messaggio e le risponderemo
+	il prima possibile. Grazie per la sua richiesta, può trovare maggiori
+	informazioni sul nostro sito.
+	A che ora arriva il treno domani? Ho dimenticato la mia password e non
+	riesco a vedere i miei ordini.`,
+	"nl": `Alle mensen worden vrij en gelijk in waardigheid en rechten geboren.
+	Zij zijn begiftigd met verstand en geweten, en behoren zich jegens elkander
+	in een geest van broederschap te gedragen.
+	Een ieder heeft het recht op leven, vrijheid en onschendbaarheid van
+	zijn persoon. Wij hebben uw bericht ontvangen en nemen zo snel mogelijk
+	contact met u op. Bedankt voor uw aanvraag, meer informatie vindt u op
+	onze website.
+	Hoe laat komt de trein morgen aan? Ik ben mijn wachtwoord vergeten en
+	kan mijn bestellingen niet zien.`,
+	"pt": `Todos os seres humanos nascem livres e iguais em dignidade e em
+	direitos. Dotados de razão e de consciência, devem agir uns para com os
+	outros em espírito de fraternidade.
+	Todo indivíduo tem direito à vida, à liberdade e à segurança pessoal.
+	Recebemos a sua mensagem e responderemos o mais rapidamente possível.
+	Obrigado pelo seu pedido, pode encontrar mais informações no nosso
+	site.
+	A que horas chega o comboio amanhã? Esqueci a minha palavra-passe e
+	não consigo ver as minhas encomendas.`,
+	"ru": `Все люди рождаются свободными и равными в своем достоинстве и правах.
+	Они наделены разумом и совестью и должны поступать в отношении друг друга в
+	духе братства.
+	Каждый человек имеет право на жизнь, на свободу и на личную
+	неприкосновенность. Мы получили ваше сообщение и ответим вам как можно
+	скорее. Спасибо за ваш запрос, больше информации можно найти на нашем
+	сайте.
+	Когда завтра прибывает поезд? Я забыл свой пароль и не могу увидеть
+	свои заказы.`,
+	"sv": `Alla människor är födda fria och lika i värde och rättigheter. De har
+	utrustats med förnuft och samvete och bör handla gentemot varandra i en
+	anda av broderskap.
+	Var och en har rätt till liv, frihet och personlig säkerhet. Vi har
+	tagit emot ditt meddelande och återkommer så snart som möjligt. Tack för
+	din förfrågan, mer information finns på vår webbplats.
+	När kommer tåget i morgon? Jag har glömt mitt lösenord och kan inte se
+	mina beställningar.`,
+}
@@ -0,0 +1,115 @@
+package go_tf_idf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectLanguage(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "english",
+			text: "Please refund the payment, the product arrived broken.",
+			want: "en",
+		},
+		{
+			name: "german",
+			text: "Das Wetter in den Bergen war kalt, aber schön.",
+			want: "de",
+		},
+		{
+			name: "french",
+			text: "Veuillez rembourser le paiement, le produit est arrivé cassé.",
+			want: "fr",
+		},
+		{
+			name: "spanish",
+			text: "El tiempo en las montañas era frío pero precioso.",
+			want: "es",
+		},
+		{
+			name: "italian",
+			text: "Per favore rimborsate il pagamento, il prodotto è arrivato rotto.",
+			want: "it",
+		},
+		{
+			name: "dutch",
+			text: "Het weer in de bergen was koud maar prachtig.",
+			want: "nl",
+		},
+		{
+			name: "portuguese",
+			text: "Por favor, reembolsem o pagamento, o produto chegou partido.",
+			want: "pt",
+		},
+		{
+			name: "swedish",
+			text: "Vädret i bergen var kallt men vackert.",
+			want: "sv",
+		},
+		{
+			name: "danish",
+			text: "Venligst refunder betalingen, produktet ankom i stykker.",
+			want: "da",
+		},
+		{
+			name: "russian",
+			text: "Погода в горах была холодной, но красивой.",
+			want: "ru",
+		},
+		{
+			name: "no letters",
+			text: "1234 !?",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DetectLanguage(tt.text); got != tt.want {
+				t.Errorf("DetectLanguage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguageProfile_Distance(t *testing.T) {
+	profile := NewLanguageProfile("abc")
+	if got := profile.Distance(profile); got != 0 {
+		t.Errorf("Distance() = %v, want %v", got, 0)
+	}
+
+	if got, want := profile.Distance(LanguageProfile{}), len(profile)*LanguageProfileSize; got != want {
+		t.Errorf("Distance() = %v, want %v", got, want)
+	}
+}
+
+func TestTfIdf_WithLanguageDetection(t *testing.T) {
+	german := "Die Häuser in den Bergen waren kalt, aber schön."
+	english := "The houses in the mountains were cold but beautiful."
+	i := New(
+		WithLanguageDetection(),
+		WithDocuments([]string{german, english}),
+	)
+
+	doc := i.GetDocument(german)
+	if got, want := doc.Language, "de"; got != want {
+		t.Errorf("Language = %v, want %v", got, want)
+	}
+
+	if want := []string{"haus", "berg", "kalt", "schon"}; !reflect.DeepEqual(doc.UniqueTokens, want) {
+		t.Errorf("UniqueTokens = %v, want %v", doc.UniqueTokens, want)
+	}
+
+	if got, want := i.GetDocument(english).Language, "en"; got != want {
+		t.Errorf("Language = %v, want %v", got, want)
+	}
+
+	results := i.Search("Das Haus in den Bergen", 0)
+	if len(results) != 1 || results[0].ID != doc.ID {
+		t.Errorf("Search() = %v, want only %v", results, doc.ID)
+	}
+}
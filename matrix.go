@@ -0,0 +1,171 @@
+package go_tf_idf
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+)
+
+// SimilarityRow holds the similarities of the document stored under ID to the
+// other documents of the corpus, ordered by descending score.
+type SimilarityRow struct {
+	ID      string
+	Similar []SearchResult
+}
+
+type SimilarityMatrixOption func(config *similarityMatrixConfig)
+
+type similarityMatrixConfig struct {
+	workers      int
+	threshold    float64
+	hasThreshold bool
+}
+
+// WithSimilarityThreshold leaves out of every row the documents less similar
+// than threshold.
+func WithSimilarityThreshold(threshold float64) SimilarityMatrixOption {
+	return func(config *similarityMatrixConfig) {
+		config.threshold = threshold
+		config.hasThreshold = true
+	}
+}
+
+// WithSimilarityWorkers sets the number of goroutines computing rows. It
+// defaults to GOMAXPROCS.
+func WithSimilarityWorkers(workers int) SimilarityMatrixOption {
+	return func(config *similarityMatrixConfig) {
+		if workers > 0 {
+			config.workers = workers
+		}
+	}
+}
+
+// SimilarityMatrix compares every document of the corpus to every other with
+// the configured comparator and returns one row per document, ordered by ID.
+// Use StreamSimilarityMatrix to avoid holding the whole matrix in memory.
+func (i *TfIdf) SimilarityMatrix(opts ...SimilarityMatrixOption) []SimilarityRow {
+	rows := make([]SimilarityRow, 0)
+	_ = i.StreamSimilarityMatrix(func(row SimilarityRow) error {
+		rows = append(rows, row)
+		return nil
+	}, opts...)
+
+	sort.Slice(rows, func(a, b int) bool {
+		return rows[a].ID < rows[b].ID
+	})
+
+	return rows
+}
+
+// StreamSimilarityMatrix computes the rows of SimilarityMatrix in parallel and
+// passes each to emit as soon as it is complete, in no particular order. emit
+// is never called concurrently. Streaming stops at the first error returned by
+// emit, which is returned.
+//
+// Every document is vectorized once before the rows are computed, so the
+// TfIdf may be modified while rows are streamed without affecting them.
+func (i *TfIdf) StreamSimilarityMatrix(emit func(row SimilarityRow) error, opts ...SimilarityMatrixOption) error {
+	config := similarityMatrixConfig{workers: runtime.GOMAXPROCS(0)}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	ids, compare := i.similarityVectors()
+
+	jobs := make(chan int)
+	rows := make(chan SimilarityRow)
+	done := make(chan struct{})
+	go func() {
+		defer close(jobs)
+		for row := range ids {
+			select {
+			case jobs <- row:
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < config.workers; worker++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for row := range jobs {
+				scores := make(map[string]float64, 0)
+				for column, id := range ids {
+					if column == row {
+						continue
+					}
+
+					score := compare(row, column)
+					if !config.hasThreshold || score >= config.threshold {
+						scores[id] = score
+					}
+				}
+
+				select {
+				case rows <- SimilarityRow{ID: ids[row], Similar: rankResults(scores, 0)}:
+				case <-done:
+					return
+				}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(rows)
+	}()
+
+	var err error
+	for row := range rows {
+		if err != nil {
+			continue
+		}
+
+		if err = emit(row); err != nil {
+			close(done)
+		}
+	}
+
+	return err
+}
+
+// similarityVectors returns the IDs of the documents and a function comparing
+// the documents at two positions of the IDs. The documents are vectorized
+// before it returns, so the function does not access the TfIdf.
+func (i *TfIdf) similarityVectors() ([]string, func(row, column int) float64) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	ids := make([]string, 0, len(i.Documents))
+	for id := range i.Documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	if i.sparseComparator != nil {
+		comparator := i.sparseComparator
+		vectors := make([]SparseVector, len(ids))
+		for index, id := range ids {
+			vectors[index] = i.sparseVector(i.compareWeights(i.Documents[id]))
+		}
+
+		return ids, func(row, column int) float64 {
+			return comparator(vectors[row], vectors[column])
+		}
+	}
+
+	comparator := i.comparator
+	terms := make([][]string, len(ids))
+	weights := make([]map[string]float64, len(ids))
+	for index, id := range ids {
+		terms[index] = i.Documents[id].UniqueTokens
+		weights[index] = i.compareWeights(i.Documents[id])
+	}
+
+	return ids, func(row, column int) float64 {
+		return comparator(alignWeights(terms[row], weights[row], terms[column], weights[column]))
+	}
+}
@@ -0,0 +1,164 @@
+package go_tf_idf
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestTfIdf_SimilarityMatrix(t *testing.T) {
+	documents := map[string]string{
+		"a": "cat sat mat",
+		"b": "cat sat rug",
+		"c": "cat lay bed",
+		"d": "dog ran far",
+	}
+	tests := []struct {
+		name        string
+		opts        []Option
+		matrixOpts  []SimilarityMatrixOption
+		wantColumns map[string][]string
+	}{
+		{
+			name: "all pairs",
+			wantColumns: map[string][]string{
+				"a": {"b", "c", "d"},
+				"b": {"a", "c", "d"},
+				"c": {"a", "b", "d"},
+				"d": {"a", "b", "c"},
+			},
+		},
+		{
+			name:       "threshold",
+			matrixOpts: []SimilarityMatrixOption{WithSimilarityThreshold(0.5)},
+			wantColumns: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+				"c": {},
+				"d": {},
+			},
+		},
+		{
+			name:       "single worker",
+			matrixOpts: []SimilarityMatrixOption{WithSimilarityWorkers(1), WithSimilarityThreshold(0.5)},
+			wantColumns: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+				"c": {},
+				"d": {},
+			},
+		},
+		{
+			name:       "tf-idf mode",
+			opts:       []Option{WithCompareMode(CompareTermFrequencyInverseDocumentFrequency)},
+			matrixOpts: []SimilarityMatrixOption{WithSimilarityThreshold(0.1)},
+			wantColumns: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+				"c": {},
+				"d": {},
+			},
+		},
+		{
+			name:       "sparse comparator",
+			opts:       []Option{WithSparseComparator(SparseCosineComparator)},
+			matrixOpts: []SimilarityMatrixOption{WithSimilarityThreshold(0.5)},
+			wantColumns: map[string][]string{
+				"a": {"b"},
+				"b": {"a"},
+				"c": {},
+				"d": {},
+			},
+		},
+		{
+			name: "pearson comparator",
+			opts: []Option{WithComparator(PearsonComparator)},
+			wantColumns: map[string][]string{
+				"a": {"b", "c", "d"},
+				"b": {"a", "c", "d"},
+				"c": {"a", "b", "d"},
+				"d": {"a", "b", "c"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(tt.opts...)
+			for id, document := range documents {
+				if err := i.AddDocumentWithID(id, document); err != nil {
+					t.Fatalf("AddDocumentWithID() error = %v", err)
+				}
+			}
+
+			rows := i.SimilarityMatrix(tt.matrixOpts...)
+			if len(rows) != len(tt.wantColumns) {
+				t.Fatalf("SimilarityMatrix() has %d rows, want %d", len(rows), len(tt.wantColumns))
+			}
+
+			for index, row := range rows {
+				if index > 0 && rows[index-1].ID >= row.ID {
+					t.Errorf("SimilarityMatrix() rows not ordered by ID: %v", rows)
+				}
+
+				got := make(map[string]float64, len(row.Similar))
+				for k, result := range row.Similar {
+					if k > 0 && row.Similar[k-1].Score < result.Score {
+						t.Errorf("SimilarityMatrix() row %v not ordered by score: %v", row.ID, row.Similar)
+					}
+					got[result.ID] = result.Score
+				}
+
+				want := tt.wantColumns[row.ID]
+				if len(got) != len(want) {
+					t.Fatalf("SimilarityMatrix() row %v = %v, want columns %v", row.ID, row.Similar, want)
+				}
+
+				for _, id := range want {
+					score, ok := got[id]
+					if !ok {
+						t.Fatalf("SimilarityMatrix() row %v = %v, want columns %v", row.ID, row.Similar, want)
+					}
+
+					compared, _ := i.CompareByID(row.ID, id)
+					if math.Abs(score-compared) > 1e-12 {
+						t.Errorf("SimilarityMatrix() row %v column %v = %v, want %v", row.ID, id, score, compared)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestTfIdf_StreamSimilarityMatrix(t *testing.T) {
+	i := New()
+	for id, document := range map[string]string{"a": "cat sat mat", "b": "cat sat rug", "c": "dog ran far"} {
+		if err := i.AddDocumentWithID(id, document); err != nil {
+			t.Fatalf("AddDocumentWithID() error = %v", err)
+		}
+	}
+
+	seen := make(map[string]bool, 0)
+	err := i.StreamSimilarityMatrix(func(row SimilarityRow) error {
+		seen[row.ID] = true
+		return nil
+	}, WithSimilarityWorkers(2))
+	if err != nil {
+		t.Fatalf("StreamSimilarityMatrix() error = %v", err)
+	}
+	if len(seen) != 3 {
+		t.Errorf("StreamSimilarityMatrix() emitted rows %v, want a, b and c", seen)
+	}
+
+	stop := errors.New("stop")
+	emitted := 0
+	err = i.StreamSimilarityMatrix(func(row SimilarityRow) error {
+		emitted++
+		return stop
+	}, WithSimilarityWorkers(2))
+	if err != stop {
+		t.Errorf("StreamSimilarityMatrix() error = %v, want %v", err, stop)
+	}
+	if emitted != 1 {
+		t.Errorf("StreamSimilarityMatrix() emitted %d rows after error, want 1", emitted)
+	}
+}
@@ -0,0 +1,233 @@
+package go_tf_idf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// JSONModelVersion is the version of the JSON model schema written by
+// ExportJSON.
+const JSONModelVersion = 1
+
+var ErrInvalidModel = errors.New("invalid model")
+
+// JSONModel is the JSON representation of the vocabulary and corpus
+// statistics of a TfIdf, written by ExportJSON and read by ImportJSON. The
+// model of the documents "a document" and "another document", added with IDs
+// 1 and 2, is:
+//
+//	{
+//	  "version": 1,
+//	  "document_count": 2,
+//	  "smooth_idf": false,
+//	  "weighting": "",
+//	  "average_document_length": 2,
+//	  "average_unique_terms": 2,
+//	  "terms": [
+//	    {"term": "a", "index": 0, "document_frequency": 1, "idf": 0.3010299956639812},
+//	    {"term": "document", "index": 1, "document_frequency": 2, "idf": 0},
+//	    {"term": "another", "index": 2, "document_frequency": 1, "idf": 0.3010299956639812}
+//	  ],
+//	  "documents": [
+//	    {"id": "1", "indices": [0], "values": [0.1505149978319906]},
+//	    {"id": "2", "indices": [2], "values": [0.1505149978319906]}
+//	  ]
+//	}
+//
+// Terms are listed in index order and idf is the inverse document frequency
+// applied to a term. Without weighting, idf is log10(N/df), or
+// log10((1+N)/(1+df))+1 if smooth_idf is set, where N is document_count and df
+// is document_frequency, and a term t counted c times in a text of n tokens
+// has the weight c/n*idf(t) at its index of the text's vector. Otherwise
+// weighting is the SMART notation of the Weighting, such as "nsc", and idf is
+// its natural logarithm variant, ln((1+N)/(1+df))+1 for "s". languages counts
+// the documents per language detected by WithLanguageDetector, from which the
+// language of a query is chosen, and is left out without detection. documents
+// holds the non-zero entries of the vector of every document and is only
+// written on request.
+type JSONModel struct {
+	Version               int            `json:"version"`
+	DocumentCount         int            `json:"document_count"`
+	SmoothIdf             bool           `json:"smooth_idf"`
+	Weighting             string         `json:"weighting"`
+	AverageDocumentLength float64        `json:"average_document_length"`
+	AverageUniqueTerms    float64        `json:"average_unique_terms"`
+	Languages             map[string]int `json:"languages,omitempty"`
+	Terms                 []JSONTerm     `json:"terms"`
+	Documents             []JSONDocument `json:"documents,omitempty"`
+}
+
+type JSONTerm struct {
+	Term                     string  `json:"term"`
+	Index                    int     `json:"index"`
+	DocumentFrequency        int     `json:"document_frequency"`
+	InverseDocumentFrequency float64 `json:"idf"`
+}
+
+type JSONDocument struct {
+	ID      string    `json:"id"`
+	Indices []int     `json:"indices"`
+	Values  []float64 `json:"values"`
+}
+
+// corpusStats holds frozen corpus statistics, used instead of those of the
+// documents of a TfIdf. fitted is set if they were computed by Fit from the
+// documents, rather than imported without documents by ImportJSON.
+type corpusStats struct {
+	documentCount              int
+	documentFrequencies        map[string]int
+	inverseDocumentFrequencies map[string]float64
+	averageDocumentLength      float64
+	averageUniqueTerms         float64
+	languages                  map[string]int
+	fitted                     bool
+}
+
+// ExportJSON writes the vocabulary and corpus statistics of the TfIdf to w as
+// a JSONModel. If vectors is set, the sparse vectors of all documents, as
+// returned by SparseVectorForDocumentByID, are included.
+func (i *TfIdf) ExportJSON(w io.Writer, vectors bool) error {
+	return json.NewEncoder(w).Encode(i.jsonModel(vectors))
+}
+
+func (i *TfIdf) jsonModel(vectors bool) JSONModel {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	model := JSONModel{
+		Version:               JSONModelVersion,
+		DocumentCount:         i.documentCount(),
+		SmoothIdf:             i.smoothIdf,
+		AverageDocumentLength: i.averageDocumentLength(),
+		AverageUniqueTerms:    i.averageUniqueTerms(),
+		Terms:                 make([]JSONTerm, len(i.termToIndex)),
+	}
+	if i.weighting != nil {
+		model.Weighting = i.weighting.String()
+	}
+	if i.detector != nil {
+		model.Languages = make(map[string]int, 0)
+		for lang, count := range i.languageCounts() {
+			if lang != "" {
+				model.Languages[lang] = count
+			}
+		}
+	}
+
+	for term, index := range i.termToIndex {
+		model.Terms[index] = JSONTerm{
+			Term:                     term,
+			Index:                    index,
+			DocumentFrequency:        i.documentFrequency(term),
+			InverseDocumentFrequency: i.appliedInverseDocumentFrequency(term),
+		}
+	}
+
+	if !vectors {
+		return model
+	}
+
+	ids := make([]string, 0, len(i.Documents))
+	for id := range i.Documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	model.Documents = make([]JSONDocument, len(ids))
+	for k, id := range ids {
+		vector := i.sparseVector(i.termWeights(i.Documents[id]))
+		model.Documents[k] = JSONDocument{ID: id, Indices: vector.Indices, Values: vector.Values}
+	}
+
+	return model
+}
+
+// ImportJSON creates a scoring-only TfIdf from a JSONModel read from r. It has
+// the vocabulary, corpus statistics, smoothing and Weighting of the model but
+// no documents, and weighs texts passed to Transform and SparseVectorForQuery
+// like the exporting TfIdf, given the same text analysis options. Its
+// statistics are frozen: adding, updating or removing documents returns
+// ErrFrozen. Document vectors in the model are ignored.
+func ImportJSON(r io.Reader, opts ...Option) (*TfIdf, error) {
+	model := JSONModel{}
+	if err := json.NewDecoder(r).Decode(&model); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+	}
+
+	if model.Version != JSONModelVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d", ErrInvalidModel, model.Version)
+	}
+
+	var weighting *Weighting
+	if model.Weighting != "" {
+		w, err := ParseWeighting(model.Weighting)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidModel, err)
+		}
+		weighting = &w
+	}
+
+	stats := &corpusStats{
+		documentCount:              model.DocumentCount,
+		documentFrequencies:        make(map[string]int, len(model.Terms)),
+		inverseDocumentFrequencies: make(map[string]float64, len(model.Terms)),
+		averageDocumentLength:      model.AverageDocumentLength,
+		averageUniqueTerms:         model.AverageUniqueTerms,
+		languages:                  model.Languages,
+	}
+	termToIndex := make(map[string]int, len(model.Terms))
+	indices := make([]bool, len(model.Terms))
+	for _, term := range model.Terms {
+		if term.Index < 0 || term.Index >= len(indices) || indices[term.Index] {
+			return nil, fmt.Errorf("%w: invalid index %d of term %q", ErrInvalidModel, term.Index, term.Term)
+		}
+		if _, ok := termToIndex[term.Term]; ok {
+			return nil, fmt.Errorf("%w: duplicate term %q", ErrInvalidModel, term.Term)
+		}
+
+		indices[term.Index] = true
+		termToIndex[term.Term] = term.Index
+		stats.documentFrequencies[term.Term] = term.DocumentFrequency
+		stats.inverseDocumentFrequencies[term.Term] = term.InverseDocumentFrequency
+	}
+
+	tfIdf := New(opts...)
+	tfIdf.Documents = make(map[string]Document, 0)
+	tfIdf.index = newInvertedIndex()
+	tfIdf.termToIndex = termToIndex
+	tfIdf.vocabulary = vocabularyOf(termToIndex)
+	tfIdf.smoothIdf = model.SmoothIdf
+	tfIdf.weighting = weighting
+	tfIdf.frozen = stats
+
+	return tfIdf, nil
+}
+
+// documentFrequency returns the number of documents containing term.
+func (i *TfIdf) documentFrequency(term string) int {
+	if i.frozen != nil {
+		return i.frozen.documentFrequencies[term]
+	}
+
+	return i.index.documentFrequency(term)
+}
+
+// languageCounts returns the number of documents per detected language.
+func (i *TfIdf) languageCounts() map[string]int {
+	if i.frozen != nil {
+		return i.frozen.languages
+	}
+
+	return i.index.languages
+}
+
+func (i *TfIdf) documentCount() int {
+	if i.frozen != nil {
+		return i.frozen.documentCount
+	}
+
+	return len(i.Documents)
+}
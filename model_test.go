@@ -0,0 +1,267 @@
+package go_tf_idf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTfIdf_ExportJSON(t *testing.T) {
+	i := New()
+	_ = i.AddDocumentWithID("1", "a document")
+	_ = i.AddDocumentWithID("2", "another document")
+
+	idf := math.Log10(2)
+	tests := []struct {
+		name    string
+		vectors bool
+		want    JSONModel
+	}{
+		{
+			name: "without vectors",
+			want: JSONModel{
+				Version:               JSONModelVersion,
+				DocumentCount:         2,
+				AverageDocumentLength: 2,
+				AverageUniqueTerms:    2,
+				Terms: []JSONTerm{
+					{Term: "a", Index: 0, DocumentFrequency: 1, InverseDocumentFrequency: idf},
+					{Term: "document", Index: 1, DocumentFrequency: 2},
+					{Term: "another", Index: 2, DocumentFrequency: 1, InverseDocumentFrequency: idf},
+				},
+			},
+		},
+		{
+			name:    "with vectors",
+			vectors: true,
+			want: JSONModel{
+				Version:               JSONModelVersion,
+				DocumentCount:         2,
+				AverageDocumentLength: 2,
+				AverageUniqueTerms:    2,
+				Terms: []JSONTerm{
+					{Term: "a", Index: 0, DocumentFrequency: 1, InverseDocumentFrequency: idf},
+					{Term: "document", Index: 1, DocumentFrequency: 2},
+					{Term: "another", Index: 2, DocumentFrequency: 1, InverseDocumentFrequency: idf},
+				},
+				Documents: []JSONDocument{
+					{ID: "1", Indices: []int{0}, Values: []float64{idf / 2}},
+					{ID: "2", Indices: []int{2}, Values: []float64{idf / 2}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			if err := i.ExportJSON(&buf, tt.vectors); err != nil {
+				t.Fatalf("ExportJSON() error = %v", err)
+			}
+
+			got := JSONModel{}
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("ExportJSON() wrote invalid JSON: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExportJSON() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImportJSON(t *testing.T) {
+	documents := []string{
+		"the cat sat on the mat",
+		"the dog sat on the log",
+		"cats and dogs are pets",
+	}
+	queries := []string{"the cat sat", "dogs are loyal pets", "unknown words only"}
+
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "default",
+		},
+		{
+			name: "smooth inverse document frequency",
+			opts: []Option{WithSmoothInverseDocumentFrequency()},
+		},
+		{
+			name: "pivoted weighting",
+			opts: []Option{WithWeighting("ltu")},
+		},
+		{
+			name: "stop words and n-grams",
+			opts: []Option{WithDefaultStopWords(), WithNGramRange(1, 2)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exported := New(append(tt.opts, WithDocuments(documents))...)
+			buf := bytes.Buffer{}
+			if err := exported.ExportJSON(&buf, false); err != nil {
+				t.Fatalf("ExportJSON() error = %v", err)
+			}
+
+			imported, err := ImportJSON(&buf, tt.opts...)
+			if err != nil {
+				t.Fatalf("ImportJSON() error = %v", err)
+			}
+
+			for _, query := range queries {
+				want := exported.SparseVectorForQuery(query)
+				got := imported.SparseVectorForQuery(query)
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("SparseVectorForQuery(%q) = %v, want %v", query, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestImportJSON_Weighting(t *testing.T) {
+	exported := New(WithWeighting("nsc"))
+	_ = exported.AddDocumentWithID("1", "a document")
+	_ = exported.AddDocumentWithID("2", "another document")
+
+	buf := bytes.Buffer{}
+	if err := exported.ExportJSON(&buf, false); err != nil {
+		t.Fatalf("ExportJSON() error = %v", err)
+	}
+
+	model := JSONModel{}
+	if err := json.Unmarshal(buf.Bytes(), &model); err != nil {
+		t.Fatalf("ExportJSON() wrote invalid JSON: %v", err)
+	}
+	if model.Weighting != "nsc" {
+		t.Errorf("ExportJSON() weighting = %q, want %q", model.Weighting, "nsc")
+	}
+	wantIdfs := []float64{math.Log(1.5) + 1, 1, math.Log(1.5) + 1}
+	for index, term := range model.Terms {
+		if term.InverseDocumentFrequency != wantIdfs[index] {
+			t.Errorf("ExportJSON() idf of %q = %v, want %v", term.Term, term.InverseDocumentFrequency, wantIdfs[index])
+		}
+	}
+
+	imported, err := ImportJSON(&buf)
+	if err != nil {
+		t.Fatalf("ImportJSON() error = %v", err)
+	}
+
+	for _, query := range []string{"a document", "another document", "a new document"} {
+		want := exported.SparseVectorForQuery(query)
+		if got := imported.SparseVectorForQuery(query); !reflect.DeepEqual(got, want) {
+			t.Errorf("SparseVectorForQuery(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestImportJSON_Languages(t *testing.T) {
+	exported := New(WithLanguageDetection(), WithDocuments([]string{
+		"She was running through the parks near the river every morning before work.",
+		"The new system reports every error to the team that maintains the service.",
+		"Please send the invoice for the last order to our accounting department.",
+		"Ich habe mein Passwort vergessen und kann meine Bestellungen nicht sehen.",
+	}))
+
+	buf := bytes.Buffer{}
+	if err := exported.ExportJSON(&buf, false); err != nil {
+		t.Fatalf("ExportJSON() error = %v", err)
+	}
+
+	model := JSONModel{}
+	if err := json.Unmarshal(buf.Bytes(), &model); err != nil {
+		t.Fatalf("ExportJSON() wrote invalid JSON: %v", err)
+	}
+	if want := map[string]int{"de": 1, "en": 3}; !reflect.DeepEqual(model.Languages, want) {
+		t.Errorf("ExportJSON() languages = %v, want %v", model.Languages, want)
+	}
+
+	imported, err := ImportJSON(&buf, WithLanguageDetection())
+	if err != nil {
+		t.Fatalf("ImportJSON() error = %v", err)
+	}
+
+	for _, query := range []string{"running", "Bestellungen", "the parks near the river"} {
+		want := exported.SparseVectorForQuery(query)
+		if len(want.Indices) == 0 {
+			t.Fatalf("SparseVectorForQuery(%q) of the exporting model is empty", query)
+		}
+
+		if got := imported.SparseVectorForQuery(query); !reflect.DeepEqual(got, want) {
+			t.Errorf("SparseVectorForQuery(%q) = %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestImportJSON_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{
+			name: "malformed",
+			data: `{"version": 1, "terms": [`,
+		},
+		{
+			name: "unsupported version",
+			data: `{"version": 2, "terms": []}`,
+		},
+		{
+			name: "index out of range",
+			data: `{"version": 1, "terms": [{"term": "a", "index": 1}]}`,
+		},
+		{
+			name: "duplicate index",
+			data: `{"version": 1, "terms": [{"term": "a", "index": 0}, {"term": "b", "index": 0}]}`,
+		},
+		{
+			name: "invalid weighting",
+			data: `{"version": 1, "weighting": "xyz", "terms": []}`,
+		},
+		{
+			name: "duplicate term",
+			data: `{"version": 1, "terms": [{"term": "a", "index": 0}, {"term": "a", "index": 1}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ImportJSON(strings.NewReader(tt.data)); !errors.Is(err, ErrInvalidModel) {
+				t.Errorf("ImportJSON() error = %v, want %v", err, ErrInvalidModel)
+			}
+		})
+	}
+}
+
+func TestImportJSON_Frozen(t *testing.T) {
+	i, err := ImportJSON(strings.NewReader(`{"version": 1, "document_count": 1, "terms": [{"term": "a", "index": 0, "document_frequency": 1}]}`))
+	if err != nil {
+		t.Fatalf("ImportJSON() error = %v", err)
+	}
+
+	if err := i.AddDocumentWithID("1", "a"); err != ErrFrozen {
+		t.Errorf("AddDocumentWithID() error = %v, want %v", err, ErrFrozen)
+	}
+	if err := i.UpdateDocument("1", "a"); err != ErrFrozen {
+		t.Errorf("UpdateDocument() error = %v, want %v", err, ErrFrozen)
+	}
+	if err := i.RemoveDocumentByID("1"); err != ErrFrozen {
+		t.Errorf("RemoveDocumentByID() error = %v, want %v", err, ErrFrozen)
+	}
+	if err := i.Save(&bytes.Buffer{}); err != ErrFrozen {
+		t.Errorf("Save() error = %v, want %v", err, ErrFrozen)
+	}
+	if got := i.Prune(); len(got) != 0 {
+		t.Errorf("Prune() = %v, want no terms", got)
+	}
+	if got, err := i.LookupInverseDocumentFrequency("a"); err != nil || got != 0 {
+		t.Errorf("LookupInverseDocumentFrequency() = %v, %v, want 0, nil", got, err)
+	}
+}
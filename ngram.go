@@ -0,0 +1,98 @@
+package go_tf_idf
+
+import "strings"
+
+// NGramStopWords selects how word n-grams treat the stop words between terms.
+type NGramStopWords int
+
+const (
+	// NGramSkipStopWords removes stop words before building n-grams, so that
+	// "bank of america" yields the bigram "bank america".
+	NGramSkipStopWords NGramStopWords = iota
+	// NGramBreakAtStopWords builds no n-gram across a stop word, so that
+	// "bank of america" yields no bigram.
+	NGramBreakAtStopWords
+	// NGramKeepStopWords keeps stop words inside n-grams but not at their
+	// start or end, so that "bank of america" yields the trigram
+	// "bank of america".
+	NGramKeepStopWords
+)
+
+// WithNGramRange counts every run of min to max consecutive terms, joined by a
+// space, as a term of its own. Stop words are handled as set by
+// WithNGramStopWords. The option is ignored if min is less than one or max
+// less than min.
+func WithNGramRange(min, max int) Option {
+	return func(tfIdf *TfIdf) {
+		if min < 1 || max < min {
+			return
+		}
+
+		tfIdf.nGramMin = min
+		tfIdf.nGramMax = max
+	}
+}
+
+// WithNGramStopWords sets how n-grams treat stop words. The default is
+// NGramSkipStopWords.
+func WithNGramStopWords(mode NGramStopWords) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.nGramStopWords = mode
+	}
+}
+
+// occurrence is a term and the position of the token it starts at.
+type occurrence struct {
+	term     string
+	position int
+}
+
+// terms returns the occurrences of the terms and n-grams of tokens, in a text
+// of language lang, ordered by position.
+func (i *TfIdf) terms(tokens []string, lang string) []occurrence {
+	words := make([]occurrence, 0, len(tokens))
+	stop := make([]bool, 0, len(tokens))
+	for position, token := range tokens {
+		term, ok := i.term(token, lang)
+		if !ok {
+			if i.nGramMax == 1 || i.nGramStopWords == NGramSkipStopWords {
+				continue
+			}
+			term = token
+		}
+
+		words = append(words, occurrence{term: term, position: position})
+		stop = append(stop, !ok)
+	}
+
+	occurrences := make([]occurrence, 0, len(words))
+	for start := range words {
+		// In break mode, n-grams end before the first stop word from start.
+		end := len(words)
+		if i.nGramStopWords == NGramBreakAtStopWords {
+			end = start
+			for end < len(words) && !stop[end] {
+				end++
+			}
+		}
+
+		for n := i.nGramMin; n <= i.nGramMax && start+n <= end; n++ {
+			if stop[start] || stop[start+n-1] {
+				continue
+			}
+
+			term := words[start].term
+			if n > 1 {
+				parts := make([]string, n)
+				for index := range parts {
+					parts[index] = words[start+index].term
+				}
+				term = strings.Join(parts, " ")
+			}
+
+			occurrences = append(occurrences, occurrence{term: term, position: words[start].position})
+		}
+	}
+
+	return occurrences
+}
@@ -0,0 +1,106 @@
+package go_tf_idf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTfIdf_WithNGramRange(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		want []string
+	}{
+		{
+			name: "unigrams",
+			opts: []Option{WithNGramRange(1, 1)},
+			want: []string{"bank", "of", "america", "new", "york"},
+		},
+		{
+			name: "bigrams only",
+			opts: []Option{WithNGramRange(2, 2)},
+			want: []string{"bank of", "of america", "america new", "new york"},
+		},
+		{
+			name: "skip stop words",
+			opts: []Option{WithNGramRange(1, 2), WithStopWords([]string{"of"})},
+			want: []string{"bank", "bank america", "america", "america new", "new", "new york", "york"},
+		},
+		{
+			name: "break at stop words",
+			opts: []Option{
+				WithNGramRange(1, 3),
+				WithStopWords([]string{"of"}),
+				WithNGramStopWords(NGramBreakAtStopWords),
+			},
+			want: []string{"bank", "america", "america new", "america new york", "new", "new york", "york"},
+		},
+		{
+			name: "break at stop words without unigrams",
+			opts: []Option{
+				WithNGramRange(2, 3),
+				WithStopWords([]string{"of"}),
+				WithNGramStopWords(NGramBreakAtStopWords),
+			},
+			want: []string{"america new", "america new york", "new york"},
+		},
+		{
+			name: "break at stop words inside trigrams",
+			opts: []Option{
+				WithNGramRange(3, 3),
+				WithStopWords([]string{"of"}),
+				WithNGramStopWords(NGramBreakAtStopWords),
+			},
+			want: []string{"america new york"},
+		},
+		{
+			name: "keep stop words",
+			opts: []Option{
+				WithNGramRange(1, 3),
+				WithStopWords([]string{"of"}),
+				WithNGramStopWords(NGramKeepStopWords),
+			},
+			want: []string{"bank", "bank of america", "america", "america new", "america new york", "new", "new york", "york"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(tt.opts...)
+			if err := i.AddDocumentWithID("doc", "Bank of America, New York"); err != nil {
+				t.Fatalf("AddDocumentWithID() error = %v", err)
+			}
+
+			if got := i.GetDocumentByID("doc").UniqueTokens; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UniqueTokens = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_SearchNGrams(t *testing.T) {
+	i := New(
+		WithNGramRange(1, 2),
+		WithPositions(),
+		WithDocuments([]string{"machine learning is fun", "learning a machine trade"}),
+	)
+
+	if got, want := i.DocumentsWithTerm("machine learning"), []string{md5Hash("machine learning is fun")}; !reflect.DeepEqual(got, want) {
+		t.Errorf("DocumentsWithTerm() = %v, want %v", got, want)
+	}
+
+	if got, want := i.Postings("machine learning")[0].Positions, []int{0}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Positions = %v, want %v", got, want)
+	}
+
+	results := i.Search("machine learning", 0)
+	if len(results) != 2 || results[0].ID != md5Hash("machine learning is fun") {
+		t.Errorf("Search() = %v, want the bigram match first", results)
+	}
+}
+
+func TestWithNGramRange_Invalid(t *testing.T) {
+	i := New(WithNGramRange(1, 2), WithNGramRange(2, 1), WithNGramRange(0, 3))
+	if i.nGramMin != 1 || i.nGramMax != 2 {
+		t.Errorf("WithNGramRange() set range [%d, %d], want [1, 2]", i.nGramMin, i.nGramMax)
+	}
+}
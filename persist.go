@@ -0,0 +1,453 @@
+package go_tf_idf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"sort"
+)
+
+// A snapshot written by Save starts with snapshotMagic and the uvarint format
+// version, followed by sections. A section is a uvarint tag, the uvarint
+// length of its payload, the payload and the big-endian CRC-32 (IEEE) of the
+// payload. A section tagged sectionEnd without payload ends the snapshot.
+//
+// Integers within payloads are uvarints and strings are prefixed by their
+// uvarint length. Load skips sections with unknown tags and treats missing
+// sections as empty, so snapshots written by other versions of the same
+// format keep loading. The version only changes when existing sections change
+// incompatibly.
+const snapshotVersion = 1
+
+var snapshotMagic = []byte("TFIDF")
+
+const (
+	sectionEnd = iota
+	sectionDocuments
+	sectionStopWords
+	sectionVocabulary
+	sectionDocumentFrequencies
+)
+
+var (
+	ErrInvalidSnapshot     = errors.New("invalid snapshot")
+	ErrUnsupportedSnapshot = errors.New("unsupported snapshot version")
+)
+
+// Save writes the documents, stop word list, vocabulary and document
+// frequencies of the TfIdf to w. Options such as the analyzer, stemmer and
+// comparator, and stop word filters are not saved, nor are statistics frozen
+// by Fit. Save returns ErrFrozen for a scoring-only TfIdf created by
+// ImportJSON, which can be exported with ExportJSON instead.
+func (i *TfIdf) Save(w io.Writer) error {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if i.frozen != nil && !i.frozen.fitted {
+		return ErrFrozen
+	}
+
+	bw := bufio.NewWriter(w)
+	header := snapshotWriter{}
+	header.Write(snapshotMagic)
+	header.uint(snapshotVersion)
+	_, _ = bw.Write(header.Bytes())
+
+	writeSection(bw, sectionDocuments, i.encodeDocuments())
+	writeSection(bw, sectionStopWords, i.encodeStopWords())
+	writeSection(bw, sectionVocabulary, i.encodeVocabulary())
+	writeSection(bw, sectionDocumentFrequencies, i.encodeDocumentFrequencies())
+	writeSection(bw, sectionEnd, nil)
+
+	return bw.Flush()
+}
+
+// Load replaces the documents, stop word list, vocabulary and document
+// frequencies of the TfIdf with those of a snapshot written by Save. The TfIdf
+// should be configured with the options of the saved one, as these are not
+// part of the snapshot. Stop word filters are kept. Token positions are only
+// restored if the TfIdf records positions. A scoring-only TfIdf becomes a
+// regular one.
+//
+// Load returns ErrInvalidSnapshot if the snapshot is malformed or fails its
+// checksums, and ErrUnsupportedSnapshot if it was written in a format version
+// newer than this package supports. The TfIdf is left unchanged on error.
+func (i *TfIdf) Load(r io.Reader) error {
+	snapshot, err := readSnapshot(r)
+	if err != nil {
+		return err
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	index := newInvertedIndex()
+	documents := make(map[string]Document, len(snapshot.documents))
+	for _, doc := range snapshot.documents {
+		if _, ok := documents[doc.ID]; ok {
+			return fmt.Errorf("%w: duplicate document %q", ErrInvalidSnapshot, doc.ID)
+		}
+
+		if !i.recordPositions {
+			doc.positions = nil
+		}
+
+		documents[doc.ID] = doc
+		index.add(doc)
+		index.totalLength += len(doc.AllTokens)
+		index.totalUnique += len(doc.UniqueTokens)
+		index.countLanguage(doc.Language, 1)
+	}
+
+	termToIndex := make(map[string]int, len(index.postings))
+	if snapshot.vocabulary != nil {
+		for _, term := range snapshot.vocabulary {
+			if _, ok := index.postings[term]; !ok {
+				return fmt.Errorf("%w: vocabulary term %q not contained in any document", ErrInvalidSnapshot, term)
+			}
+			if _, ok := termToIndex[term]; ok {
+				return fmt.Errorf("%w: duplicate vocabulary term %q", ErrInvalidSnapshot, term)
+			}
+			termToIndex[term] = len(termToIndex)
+		}
+
+		if len(termToIndex) != len(index.postings) {
+			return fmt.Errorf("%w: vocabulary misses document terms", ErrInvalidSnapshot)
+		}
+	} else {
+		for _, doc := range snapshot.documents {
+			for _, term := range doc.UniqueTokens {
+				if _, ok := termToIndex[term]; !ok {
+					termToIndex[term] = len(termToIndex)
+				}
+			}
+		}
+	}
+
+	for term, frequency := range snapshot.documentFrequencies {
+		if index.documentFrequency(term) != frequency {
+			return fmt.Errorf("%w: document frequency of %q is %d, documents contain it %d times",
+				ErrInvalidSnapshot, term, frequency, index.documentFrequency(term))
+		}
+	}
+
+	i.Documents = documents
+	i.index = index
+	i.termToIndex = termToIndex
+	i.vocabulary = vocabularyOf(termToIndex)
+	i.frozen = nil
+	if snapshot.stopWords != nil {
+		i.StopWords.List = snapshot.stopWords
+	}
+
+	return nil
+}
+
+func (i *TfIdf) encodeDocuments() []byte {
+	ids := make([]string, 0, len(i.Documents))
+	for id := range i.Documents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	w := snapshotWriter{}
+	w.uint(len(ids))
+	for _, id := range ids {
+		doc := i.Documents[id]
+		w.string(doc.ID)
+		w.string(doc.Language)
+		w.strings(doc.AllTokens)
+
+		w.uint(len(doc.UniqueTokens))
+		for _, term := range doc.UniqueTokens {
+			w.string(term)
+			w.uint(doc.TermCount[term])
+		}
+
+		if doc.positions == nil {
+			w.uint(0)
+			continue
+		}
+
+		w.uint(1)
+		for _, term := range doc.UniqueTokens {
+			w.uint(len(doc.positions[term]))
+			for _, position := range doc.positions[term] {
+				w.uint(position)
+			}
+		}
+	}
+
+	return w.Bytes()
+}
+
+func (i *TfIdf) encodeStopWords() []byte {
+	words := make([]string, 0, len(i.StopWords.List))
+	for word, ok := range i.StopWords.List {
+		if ok {
+			words = append(words, word)
+		}
+	}
+	sort.Strings(words)
+
+	w := snapshotWriter{}
+	w.strings(words)
+	return w.Bytes()
+}
+
+func (i *TfIdf) encodeVocabulary() []byte {
+	w := snapshotWriter{}
+	w.strings(i.vocabulary)
+	return w.Bytes()
+}
+
+func (i *TfIdf) encodeDocumentFrequencies() []byte {
+	terms := make([]string, 0, len(i.index.postings))
+	for term := range i.index.postings {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	w := snapshotWriter{}
+	w.uint(len(terms))
+	for _, term := range terms {
+		w.string(term)
+		w.uint(i.index.documentFrequency(term))
+	}
+
+	return w.Bytes()
+}
+
+// snapshot holds the decoded sections of a snapshot. Fields of missing
+// sections are nil.
+type snapshot struct {
+	documents           []Document
+	stopWords           map[string]bool
+	vocabulary          []string
+	documentFrequencies map[string]int
+}
+
+func readSnapshot(r io.Reader) (snapshot, error) {
+	s := snapshot{}
+	br := bufio.NewReader(r)
+
+	magic := make([]byte, len(snapshotMagic))
+	if _, err := io.ReadFull(br, magic); err != nil || !bytes.Equal(magic, snapshotMagic) {
+		return s, fmt.Errorf("%w: missing header", ErrInvalidSnapshot)
+	}
+
+	version, err := binary.ReadUvarint(br)
+	if err != nil {
+		return s, fmt.Errorf("%w: missing version", ErrInvalidSnapshot)
+	}
+	if version == 0 || version > snapshotVersion {
+		return s, fmt.Errorf("%w: %d", ErrUnsupportedSnapshot, version)
+	}
+
+	for {
+		tag, payload, err := readSection(br)
+		if err != nil {
+			return s, err
+		}
+
+		decoder := snapshotReader{data: payload}
+		switch tag {
+		case sectionEnd:
+			return s, nil
+		case sectionDocuments:
+			s.documents = decoder.documents()
+		case sectionStopWords:
+			s.stopWords = make(map[string]bool, 0)
+			for _, word := range decoder.strings() {
+				s.stopWords[word] = true
+			}
+		case sectionVocabulary:
+			s.vocabulary = decoder.strings()
+		case sectionDocumentFrequencies:
+			s.documentFrequencies = make(map[string]int, 0)
+			for n := decoder.count(); n > 0 && decoder.err == nil; n-- {
+				term := decoder.string()
+				s.documentFrequencies[term] = decoder.uint()
+			}
+		default:
+			continue
+		}
+
+		if decoder.err == nil && len(decoder.data) > 0 {
+			decoder.err = errors.New("trailing data")
+		}
+		if decoder.err != nil {
+			return s, fmt.Errorf("%w: section %d: %v", ErrInvalidSnapshot, tag, decoder.err)
+		}
+	}
+}
+
+func writeSection(w io.Writer, tag int, payload []byte) {
+	header := snapshotWriter{}
+	header.uint(tag)
+	header.uint(len(payload))
+
+	checksum := make([]byte, 4)
+	binary.BigEndian.PutUint32(checksum, crc32.ChecksumIEEE(payload))
+
+	_, _ = w.Write(header.Bytes())
+	_, _ = w.Write(payload)
+	_, _ = w.Write(checksum)
+}
+
+// readSection reads the next section and verifies its checksum.
+func readSection(r *bufio.Reader) (int, []byte, error) {
+	tag, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, nil, fmt.Errorf("%w: truncated section header", ErrInvalidSnapshot)
+	}
+
+	length, err := binary.ReadUvarint(r)
+	if err != nil || tag > maxInt || length > maxInt {
+		return 0, nil, fmt.Errorf("%w: truncated section header", ErrInvalidSnapshot)
+	}
+
+	// Copying rather than allocating length bytes up front keeps a corrupt
+	// length from allocating more memory than the snapshot holds.
+	payload := bytes.Buffer{}
+	if _, err := io.CopyN(&payload, r, int64(length)); err != nil {
+		return 0, nil, fmt.Errorf("%w: truncated section %d", ErrInvalidSnapshot, tag)
+	}
+
+	checksum := make([]byte, 4)
+	if _, err := io.ReadFull(r, checksum); err != nil {
+		return 0, nil, fmt.Errorf("%w: truncated section %d", ErrInvalidSnapshot, tag)
+	}
+	if binary.BigEndian.Uint32(checksum) != crc32.ChecksumIEEE(payload.Bytes()) {
+		return 0, nil, fmt.Errorf("%w: checksum mismatch in section %d", ErrInvalidSnapshot, tag)
+	}
+
+	return int(tag), payload.Bytes(), nil
+}
+
+const maxInt = uint64(^uint(0) >> 1)
+
+type snapshotWriter struct {
+	bytes.Buffer
+}
+
+func (w *snapshotWriter) uint(v int) {
+	buf := make([]byte, binary.MaxVarintLen64)
+	_, _ = w.Write(buf[:binary.PutUvarint(buf, uint64(v))])
+}
+
+func (w *snapshotWriter) string(s string) {
+	w.uint(len(s))
+	_, _ = w.WriteString(s)
+}
+
+func (w *snapshotWriter) strings(ss []string) {
+	w.uint(len(ss))
+	for _, s := range ss {
+		w.string(s)
+	}
+}
+
+// snapshotReader decodes a section payload. After the first error, err is set
+// and all reads return zero values.
+type snapshotReader struct {
+	data []byte
+	err  error
+}
+
+func (r *snapshotReader) uint() int {
+	if r.err != nil {
+		return 0
+	}
+
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 || v > maxInt {
+		r.err = errors.New("malformed integer")
+		return 0
+	}
+
+	r.data = r.data[n:]
+	return int(v)
+}
+
+// count reads the number of elements of a list, each of which takes at least
+// one byte, so that a corrupt count cannot cause a large allocation.
+func (r *snapshotReader) count() int {
+	n := r.uint()
+	if n > len(r.data) {
+		r.err = errors.New("malformed length")
+		return 0
+	}
+
+	return n
+}
+
+func (r *snapshotReader) string() string {
+	n := r.count()
+	if r.err != nil {
+		return ""
+	}
+
+	s := string(r.data[:n])
+	r.data = r.data[n:]
+	return s
+}
+
+func (r *snapshotReader) strings() []string {
+	ss := make([]string, r.count())
+	for k := range ss {
+		ss[k] = r.string()
+	}
+
+	return ss
+}
+
+func (r *snapshotReader) documents() []Document {
+	documents := make([]Document, r.count())
+	for k := range documents {
+		doc := Document{
+			ID:        r.string(),
+			Language:  r.string(),
+			AllTokens: r.strings(),
+		}
+
+		doc.UniqueTokens = make([]string, r.count())
+		doc.TermCount = make(map[string]int, len(doc.UniqueTokens))
+		for t := range doc.UniqueTokens {
+			term := r.string()
+			count := r.uint()
+			if _, ok := doc.TermCount[term]; ok || count == 0 {
+				r.err = fmt.Errorf("malformed terms of document %q", doc.ID)
+			}
+
+			doc.UniqueTokens[t] = term
+			doc.TermCount[term] = count
+		}
+
+		if r.uint() == 1 {
+			doc.positions = make(map[string][]int, len(doc.UniqueTokens))
+			for _, term := range doc.UniqueTokens {
+				positions := make([]int, r.count())
+				for p := range positions {
+					positions[p] = r.uint()
+				}
+				doc.positions[term] = positions
+			}
+		}
+
+		if r.err == nil && len(doc.AllTokens) == 0 {
+			r.err = fmt.Errorf("document %q has no tokens", doc.ID)
+		}
+		if r.err != nil {
+			return nil
+		}
+
+		documents[k] = doc
+	}
+
+	return documents
+}
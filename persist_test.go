@@ -0,0 +1,197 @@
+package go_tf_idf
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestTfIdf_SaveLoad(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "documents",
+			opts: []Option{WithDocuments([]string{doc1Content, doc2Content})},
+		},
+		{
+			name: "stop words and positions",
+			opts: []Option{WithDefaultStopWords(), WithPositions(), WithDocuments([]string{doc1Content, doc2Content})},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			saved := New(tt.opts...)
+			_ = saved.AddDocumentWithID("extra", "another small example")
+			_ = saved.RemoveDocumentByID(md5Hash(doc1Content))
+
+			buf := bytes.Buffer{}
+			if err := saved.Save(&buf); err != nil {
+				t.Fatalf("Save() error = %v", err)
+			}
+
+			loaded := New(tt.opts...)
+			if err := loaded.Load(&buf); err != nil {
+				t.Fatalf("Load() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(loaded.Documents, saved.Documents) {
+				t.Errorf("Load() documents = %v, want %v", loaded.Documents, saved.Documents)
+			}
+			if !reflect.DeepEqual(loaded.StopWords.List, saved.StopWords.List) {
+				t.Errorf("Load() stop words = %v, want %v", loaded.StopWords.List, saved.StopWords.List)
+			}
+			if !reflect.DeepEqual(loaded.termToIndex, saved.termToIndex) {
+				t.Errorf("Load() vocabulary = %v, want %v", loaded.termToIndex, saved.termToIndex)
+			}
+			if !reflect.DeepEqual(loaded.index, saved.index) {
+				t.Errorf("Load() index = %v, want %v", loaded.index, saved.index)
+			}
+		})
+	}
+}
+
+func TestTfIdf_Load(t *testing.T) {
+	saved := New(WithDocuments([]string{doc1Content, doc2Content}))
+	buf := bytes.Buffer{}
+	if err := saved.Save(&buf); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	snapshot := buf.Bytes()
+
+	// sections splits a snapshot written by Save into its header and sections,
+	// leaving out the end section.
+	sections := func() ([]byte, [][]byte) {
+		header := len(snapshotMagic) + 1
+		parts := make([][]byte, 0)
+		for offset := header; ; {
+			decoder := snapshotReader{data: snapshot[offset:]}
+			tag := decoder.uint()
+			length := decoder.uint()
+			end := len(snapshot) - len(decoder.data) + length + 4
+			if tag == sectionEnd {
+				return snapshot[:header], parts
+			}
+
+			parts = append(parts, snapshot[offset:end])
+			offset = end
+		}
+	}
+	join := func(header []byte, parts ...[]byte) []byte {
+		joined := append([]byte{}, header...)
+		for _, part := range parts {
+			joined = append(joined, part...)
+		}
+
+		end := bytes.Buffer{}
+		writeSection(&end, sectionEnd, nil)
+		return append(joined, end.Bytes()...)
+	}
+	unknown := bytes.Buffer{}
+	writeSection(&unknown, 99, []byte("from the future"))
+
+	header, parts := sections()
+	corrupt := append([]byte{}, snapshot...)
+	corrupt[len(header)+5] ^= 0xff
+
+	tests := []struct {
+		name      string
+		data      []byte
+		wantOrder bool
+		wantErr   error
+	}{
+		{
+			name:      "unknown section",
+			data:      join(header, append([][]byte{unknown.Bytes()}, parts...)...),
+			wantOrder: true,
+		},
+		{
+			name: "missing vocabulary and document frequencies",
+			data: join(header, parts[0], parts[1]),
+		},
+		{
+			name:    "bad magic",
+			data:    append([]byte("XXXXX"), snapshot[len(snapshotMagic):]...),
+			wantErr: ErrInvalidSnapshot,
+		},
+		{
+			name:    "newer version",
+			data:    append(append(append([]byte{}, snapshotMagic...), snapshotVersion+1), snapshot[len(header):]...),
+			wantErr: ErrUnsupportedSnapshot,
+		},
+		{
+			name:    "checksum mismatch",
+			data:    corrupt,
+			wantErr: ErrInvalidSnapshot,
+		},
+		{
+			name:    "truncated",
+			data:    snapshot[:len(snapshot)-3],
+			wantErr: ErrInvalidSnapshot,
+		},
+		{
+			name:    "vocabulary without documents",
+			data:    join(header, parts[2]),
+			wantErr: ErrInvalidSnapshot,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New()
+			_ = i.AddDocumentWithID("kept", "kept on error")
+
+			err := i.Load(bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Load() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr != nil {
+				if i.GetDocumentByID("kept") == nil {
+					t.Errorf("Load() modified the TfIdf on error")
+				}
+				return
+			}
+
+			if !reflect.DeepEqual(i.Documents, saved.Documents) {
+				t.Errorf("Load() documents = %v, want %v", i.Documents, saved.Documents)
+			}
+			if len(i.termToIndex) != len(saved.termToIndex) {
+				t.Errorf("Load() vocabulary = %v, want terms of %v", i.termToIndex, saved.termToIndex)
+			}
+			if tt.wantOrder && !reflect.DeepEqual(i.termToIndex, saved.termToIndex) {
+				t.Errorf("Load() vocabulary = %v, want %v", i.termToIndex, saved.termToIndex)
+			}
+		})
+	}
+}
+
+func TestTfIdf_LoadConcurrent(t *testing.T) {
+	buf := bytes.Buffer{}
+	if err := New(WithDefaultStopWords(), WithDocuments([]string{doc1Content, doc2Content})).Save(&buf); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	snapshot := buf.Bytes()
+
+	i := New(WithPositions())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for n := 0; n < 50; n++ {
+			_ = i.AddDocumentWithID(fmt.Sprint(n), "a new document with words")
+			_ = i.SparseVectorForQuery("new words")
+		}
+	}()
+
+	for n := 0; n < 50; n++ {
+		if err := i.Load(bytes.NewReader(snapshot)); err != nil {
+			t.Fatalf("Load() error = %v", err)
+		}
+	}
+	<-done
+}
@@ -0,0 +1,133 @@
+package go_tf_idf
+
+import "sort"
+
+// WithMinDocumentFrequency makes Prune drop terms contained in fewer than
+// count documents.
+func WithMinDocumentFrequency(count int) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.minDocumentFrequency = count
+	}
+}
+
+// WithMaxDocumentFrequency makes Prune drop terms contained in more than the
+// given ratio of the documents. The option is ignored if ratio is not in
+// (0, 1].
+func WithMaxDocumentFrequency(ratio float64) Option {
+	return func(tfIdf *TfIdf) {
+		if ratio <= 0 || ratio > 1 {
+			return
+		}
+
+		tfIdf.maxDocumentFrequency = ratio
+	}
+}
+
+// WithMaxFeatures makes Prune keep only the count terms occurring most often
+// in the corpus. Terms occurring equally often keep the earliest indexed.
+func WithMaxFeatures(count int) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.maxFeatures = count
+	}
+}
+
+// Prune drops the terms outside the limits set by WithMinDocumentFrequency,
+// WithMaxDocumentFrequency and WithMaxFeatures from the vocabulary, the
+// inverted index and the documents, and returns them sorted. Document
+// frequency limits apply before the vocabulary size limit. The remaining terms
+// keep their relative order in vectors. Token counts are left unchanged, so
+// the term frequencies of the remaining terms do not change.
+//
+// Documents added after Prune may reintroduce dropped terms; call Prune again
+// to apply the limits to them. Prune drops nothing if the corpus statistics
+// are frozen.
+func (i *TfIdf) Prune() []string {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.frozen != nil {
+		return []string{}
+	}
+
+	pruned := make(map[string]bool, 0)
+	kept := make([]string, 0, len(i.termToIndex))
+	for term := range i.termToIndex {
+		df := i.index.documentFrequency(term)
+		if df < i.minDocumentFrequency ||
+			i.maxDocumentFrequency > 0 && float64(df) > i.maxDocumentFrequency*float64(len(i.Documents)) {
+			pruned[term] = true
+			continue
+		}
+
+		kept = append(kept, term)
+	}
+
+	if i.maxFeatures > 0 && len(kept) > i.maxFeatures {
+		counts := make(map[string]int, len(kept))
+		for _, term := range kept {
+			for _, posting := range i.index.postings[term] {
+				counts[term] += posting.Frequency
+			}
+		}
+
+		sort.Slice(kept, func(a, b int) bool {
+			if counts[kept[a]] != counts[kept[b]] {
+				return counts[kept[a]] > counts[kept[b]]
+			}
+			return i.termToIndex[kept[a]] < i.termToIndex[kept[b]]
+		})
+
+		for _, term := range kept[i.maxFeatures:] {
+			pruned[term] = true
+		}
+	}
+
+	terms := make([]string, 0, len(pruned))
+	for term := range pruned {
+		terms = append(terms, term)
+	}
+	sort.Strings(terms)
+
+	if len(terms) == 0 {
+		return terms
+	}
+
+	for id, doc := range i.Documents {
+		i.Documents[id] = i.pruneDocument(doc, pruned)
+	}
+
+	for _, term := range terms {
+		delete(i.index.postings, term)
+	}
+	i.compactTerms(terms)
+
+	return terms
+}
+
+// pruneDocument returns a copy of doc without the pruned terms.
+func (i *TfIdf) pruneDocument(doc Document, pruned map[string]bool) Document {
+	termCount := make(map[string]int, len(doc.TermCount))
+	uniqueTokens := make([]string, 0, len(doc.UniqueTokens))
+	for _, term := range doc.UniqueTokens {
+		if pruned[term] {
+			continue
+		}
+
+		termCount[term] = doc.TermCount[term]
+		uniqueTokens = append(uniqueTokens, term)
+	}
+
+	var positions map[string][]int
+	if doc.positions != nil {
+		positions = make(map[string][]int, len(uniqueTokens))
+		for _, term := range uniqueTokens {
+			positions[term] = doc.positions[term]
+		}
+	}
+
+	i.index.totalUnique -= len(doc.UniqueTokens) - len(uniqueTokens)
+	doc.TermCount = termCount
+	doc.UniqueTokens = uniqueTokens
+	doc.positions = positions
+	return doc
+}
@@ -0,0 +1,99 @@
+package go_tf_idf
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTfIdf_Prune(t *testing.T) {
+	documents := []string{"apple banana cherry", "apple banana date", "apple egg egg"}
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantPruned []string
+		wantKept   []string
+	}{
+		{
+			name:       "no limits",
+			wantPruned: []string{},
+			wantKept:   []string{"apple", "banana", "cherry", "date", "egg"},
+		},
+		{
+			name:       "min document frequency",
+			opts:       []Option{WithMinDocumentFrequency(2)},
+			wantPruned: []string{"cherry", "date", "egg"},
+			wantKept:   []string{"apple", "banana"},
+		},
+		{
+			name:       "max document frequency",
+			opts:       []Option{WithMaxDocumentFrequency(0.9)},
+			wantPruned: []string{"apple"},
+			wantKept:   []string{"banana", "cherry", "date", "egg"},
+		},
+		{
+			name:       "max features",
+			opts:       []Option{WithMaxFeatures(2)},
+			wantPruned: []string{"cherry", "date", "egg"},
+			wantKept:   []string{"apple", "banana"},
+		},
+		{
+			name:       "max features after document frequency",
+			opts:       []Option{WithMaxDocumentFrequency(0.9), WithMaxFeatures(2)},
+			wantPruned: []string{"apple", "cherry", "date"},
+			wantKept:   []string{"banana", "egg"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(append(tt.opts, WithDocuments(documents))...)
+			if got := i.Prune(); !reflect.DeepEqual(got, tt.wantPruned) {
+				t.Errorf("Prune() = %v, want %v", got, tt.wantPruned)
+			}
+
+			kept := make([]string, len(i.termToIndex))
+			for term, index := range i.termToIndex {
+				kept[index] = term
+			}
+			if !reflect.DeepEqual(kept, tt.wantKept) {
+				t.Errorf("vocabulary = %v, want %v", kept, tt.wantKept)
+			}
+			if !reflect.DeepEqual(i.vocabulary, kept) {
+				t.Errorf("vocabulary = %v, want %v", i.vocabulary, kept)
+			}
+
+			for _, term := range tt.wantPruned {
+				if ids := i.DocumentsWithTerm(term); len(ids) != 0 {
+					t.Errorf("DocumentsWithTerm(%q) = %v, want none", term, ids)
+				}
+			}
+
+			if got := len(i.TermFrequencyInverseDocumentFrequencyForDocument(documents[2])); got != len(tt.wantKept) {
+				t.Errorf("len(TermFrequencyInverseDocumentFrequencyForDocument()) = %v, want %v", got, len(tt.wantKept))
+			}
+		})
+	}
+}
+
+func TestWithMaxDocumentFrequency_Invalid(t *testing.T) {
+	i := New(WithMaxDocumentFrequency(0.5), WithMaxDocumentFrequency(0), WithMaxDocumentFrequency(1.5))
+	if i.maxDocumentFrequency != 0.5 {
+		t.Errorf("WithMaxDocumentFrequency() set ratio %v, want 0.5", i.maxDocumentFrequency)
+	}
+}
+
+func TestTfIdf_PruneKeepsTermFrequency(t *testing.T) {
+	i := New(
+		WithMinDocumentFrequency(2),
+		WithDocuments([]string{"apple banana cherry", "apple banana date"}),
+	)
+	i.Prune()
+
+	doc := i.GetDocument("apple banana cherry")
+	if got, want := doc.TermFrequency("apple"), 1.0/3; got != want {
+		t.Errorf("TermFrequency() = %v, want %v", got, want)
+	}
+
+	if got, want := doc.UniqueTokens, []string{"apple", "banana"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("UniqueTokens = %v, want %v", got, want)
+	}
+}
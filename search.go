@@ -0,0 +1,150 @@
+package go_tf_idf
+
+import "sort"
+
+// TermStats holds the statistics a Scorer needs to weigh a single term in a
+// single document. InverseDocumentFrequency is the IDF the TfIdf applies to
+// the term in vectors, which is that of the Weighting if one is configured and
+// honours WithSmoothInverseDocumentFrequency and frozen or imported
+// statistics.
+type TermStats struct {
+	TermCount         int
+	DocumentLength    int
+	DocumentFrequency int
+	DocumentCount     int
+
+	AverageDocumentLength    float64
+	InverseDocumentFrequency float64
+}
+
+type Scorer func(stats TermStats) float64
+
+// TfIdfScorer weighs a term by its frequency in the document times its
+// inverse document frequency.
+func TfIdfScorer(stats TermStats) float64 {
+	if stats.TermCount == 0 {
+		return 0
+	}
+
+	tf := float64(stats.TermCount) / float64(stats.DocumentLength)
+	return tf * stats.InverseDocumentFrequency
+}
+
+func WithScorer(scorer Scorer) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.scorer = scorer
+	}
+}
+
+type SearchResult struct {
+	ID    string
+	Score float64
+}
+
+// Search returns the k documents scoring highest against query, best match
+// first. Every document is returned if k is not positive.
+func (i *TfIdf) Search(query string, k int) []SearchResult {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	scores := make(map[string]float64, 0)
+	for _, term := range i.queryTerms(query) {
+		for id := range i.index.postings[term] {
+			scores[id] += i.scorer(i.termStats(term, i.Documents[id]))
+		}
+	}
+
+	return rankResults(scores, k)
+}
+
+// rankResults orders scores by descending score, breaking ties by ID, and
+// keeps the first k, or all if k is not positive.
+func rankResults(scores map[string]float64, k int) []SearchResult {
+	results := make([]SearchResult, 0, len(scores))
+	for id, score := range scores {
+		results = append(results, SearchResult{ID: id, Score: score})
+	}
+
+	sort.Slice(results, func(a, b int) bool {
+		if results[a].Score != results[b].Score {
+			return results[a].Score > results[b].Score
+		}
+		return results[a].ID < results[b].ID
+	})
+
+	if k > 0 && len(results) > k {
+		results = results[:k]
+	}
+
+	return results
+}
+
+func (i *TfIdf) ScoreForTermByID(term string, id string) float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	doc, ok := i.Documents[id]
+	if !ok {
+		return 0
+	}
+
+	return i.scorer(i.termStats(term, doc))
+}
+
+// ScoredVectorByID returns the vector of document id over the whole
+// vocabulary, weighted by the configured Scorer.
+func (i *TfIdf) ScoredVectorByID(id string) []float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	vec := make([]float64, len(i.termToIndex))
+	doc, ok := i.Documents[id]
+	if !ok {
+		return vec
+	}
+
+	for _, term := range doc.UniqueTokens {
+		vec[i.termToIndex[term]] = i.scorer(i.termStats(term, doc))
+	}
+
+	return vec
+}
+
+func (i *TfIdf) termStats(term string, doc Document) TermStats {
+	return TermStats{
+		TermCount:                doc.TermCount[term],
+		DocumentLength:           len(doc.AllTokens),
+		DocumentFrequency:        i.documentFrequency(term),
+		DocumentCount:            i.documentCount(),
+		AverageDocumentLength:    i.averageDocumentLength(),
+		InverseDocumentFrequency: i.appliedInverseDocumentFrequency(term),
+	}
+}
+
+func (i *TfIdf) averageDocumentLength() float64 {
+	if i.frozen != nil {
+		return i.frozen.averageDocumentLength
+	}
+
+	if len(i.Documents) == 0 {
+		return 0
+	}
+
+	return float64(i.index.totalLength) / float64(len(i.Documents))
+}
+
+func (i *TfIdf) queryTerms(query string) []string {
+	visited := make(map[string]bool, 0)
+	terms := make([]string, 0)
+	lang := i.queryLanguage(i.language(query))
+	for _, occurrence := range i.terms(i.analyzer.Tokenize(query), lang) {
+		if visited[occurrence.term] {
+			continue
+		}
+
+		visited[occurrence.term] = true
+		terms = append(terms, occurrence.term)
+	}
+
+	return terms
+}
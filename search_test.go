@@ -0,0 +1,172 @@
+package go_tf_idf
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestTfIdfScorer(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats TermStats
+		want  float64
+	}{
+		{
+			name:  "'example' doc2",
+			stats: TermStats{TermCount: 3, DocumentLength: 7, DocumentFrequency: 1, DocumentCount: 2, InverseDocumentFrequency: math.Log10(2)},
+			want:  0.12901285528456335,
+		},
+		{
+			name:  "term in every document",
+			stats: TermStats{TermCount: 1, DocumentLength: 5, DocumentFrequency: 2, DocumentCount: 2},
+			want:  0,
+		},
+		{
+			name:  "smoothed idf",
+			stats: TermStats{TermCount: 1, DocumentLength: 5, DocumentFrequency: 2, DocumentCount: 2, InverseDocumentFrequency: 1},
+			want:  0.2,
+		},
+		{
+			name:  "term not in document",
+			stats: TermStats{TermCount: 0, DocumentLength: 5, DocumentFrequency: 0, DocumentCount: 2},
+			want:  0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TfIdfScorer(tt.stats); got != tt.want {
+				t.Errorf("TfIdfScorer() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_Search(t *testing.T) {
+	documents := map[string]string{
+		"1": doc1Content,
+		"2": doc2Content,
+		"3": "a sample example",
+	}
+	tests := []struct {
+		name    string
+		options []Option
+		query   string
+		k       int
+		want    []SearchResult
+	}{
+		{
+			name:  "single term",
+			query: "sample",
+			k:     10,
+			want: []SearchResult{
+				{ID: "3", Score: 0.058697086351893746},
+				{ID: "1", Score: 0.03521825181113625},
+			},
+		},
+		{
+			name:  "multiple terms limited to k",
+			query: "Another, example.",
+			k:     1,
+			want: []SearchResult{
+				{ID: "2", Score: 0.2117880409437669},
+			},
+		},
+		{
+			name:  "no limit",
+			query: "example",
+			k:     0,
+			want: []SearchResult{
+				{ID: "2", Score: 0.0754676824524348},
+				{ID: "3", Score: 0.058697086351893746},
+			},
+		},
+		{
+			name:    "smoothed idf",
+			options: []Option{WithSmoothInverseDocumentFrequency()},
+			query:   "sample",
+			k:       10,
+			want: []SearchResult{
+				{ID: "3", Score: 0.3749795788694333},
+				{ID: "1", Score: 0.22498774732166},
+			},
+		},
+		{
+			name:    "weighting idf",
+			options: []Option{WithWeighting("ntn")},
+			query:   "sample",
+			k:       10,
+			want: []SearchResult{
+				{ID: "3", Score: 0.13515503603605478},
+				{ID: "1", Score: 0.08109302162163289},
+			},
+		},
+		{
+			name:  "unknown term",
+			query: "asdf",
+			k:     10,
+			want:  []SearchResult{},
+		},
+		{
+			name: "custom scorer",
+			options: []Option{
+				WithScorer(func(stats TermStats) float64 {
+					return float64(stats.TermCount)
+				}),
+			},
+			query: "a example",
+			k:     10,
+			want: []SearchResult{
+				{ID: "2", Score: 3},
+				{ID: "1", Score: 2},
+				{ID: "3", Score: 2},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(tt.options...)
+			for _, id := range []string{"1", "2", "3"} {
+				_ = i.AddDocumentWithID(id, documents[id])
+			}
+
+			if got := i.Search(tt.query, tt.k); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Search() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_SearchWithLanguageDetection(t *testing.T) {
+	i := New(WithLanguageDetection())
+	_ = i.AddDocumentWithID("1", "She was running through the parks near the river every morning before work.")
+	_ = i.AddDocumentWithID("2", "The new system reports every error to the team that maintains the service.")
+
+	_ = i.AddDocumentWithID("3", "Please send the invoice for the last order to our accounting department.")
+	_ = i.AddDocumentWithID("4", "Ich habe mein Passwort vergessen und kann meine Bestellungen nicht sehen.")
+
+	tests := []struct {
+		query   string
+		wantIDs []string
+	}{
+		{query: "running", wantIDs: []string{"1"}},
+		{query: "parks", wantIDs: []string{"1"}},
+		{query: "system", wantIDs: []string{"2"}},
+		{query: "Bestellungen", wantIDs: []string{"4"}},
+		{query: "Passwort vergessen", wantIDs: []string{"4"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			got := i.Search(tt.query, 5)
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("Search() = %v, want %v", got, tt.wantIDs)
+			}
+
+			for index, result := range got {
+				if result.ID != tt.wantIDs[index] {
+					t.Errorf("Search()[%d] = %v, want %v", index, result.ID, tt.wantIDs[index])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package go_tf_idf
+
+import "sort"
+
+// DefaultMostSimilarTerms is the number of terms of the source document
+// MostSimilar gathers candidates with by default.
+const DefaultMostSimilarTerms = 25
+
+// WithMostSimilarTerms sets the number of highest weighted terms of the source
+// document MostSimilar gathers candidates with. A count that is not positive
+// uses every term.
+func WithMostSimilarTerms(count int) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.mostSimilarTerms = count
+	}
+}
+
+// MostSimilar returns the k documents most similar to the document stored
+// under id, or all similar documents if k is not positive. Candidates are the
+// documents sharing one of the highest weighted terms of the source document,
+// set by WithMostSimilarTerms, and are ranked by the configured comparator.
+// Documents sharing none of these terms are not returned.
+func (i *TfIdf) MostSimilar(id string, k int) ([]SearchResult, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	doc, ok := i.Documents[id]
+	if !ok {
+		return nil, ErrDocumentNotFound
+	}
+
+	weights := i.termWeights(doc)
+	terms := make([]string, 0, len(doc.UniqueTokens))
+	for _, term := range doc.UniqueTokens {
+		if weights[term] > 0 {
+			terms = append(terms, term)
+		}
+	}
+
+	sort.SliceStable(terms, func(a, b int) bool {
+		return weights[terms[a]] > weights[terms[b]]
+	})
+
+	if i.mostSimilarTerms > 0 && len(terms) > i.mostSimilarTerms {
+		terms = terms[:i.mostSimilarTerms]
+	}
+
+	compare := i.comparison(doc)
+	scores := make(map[string]float64, 0)
+	for _, term := range terms {
+		for candidate := range i.index.postings[term] {
+			if _, ok := scores[candidate]; ok || candidate == id {
+				continue
+			}
+
+			scores[candidate] = compare(i.Documents[candidate])
+		}
+	}
+
+	return rankResults(scores, k), nil
+}
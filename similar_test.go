@@ -0,0 +1,88 @@
+package go_tf_idf
+
+import (
+	"math"
+	"testing"
+)
+
+func TestTfIdf_MostSimilar(t *testing.T) {
+	documents := map[string]string{
+		"a": "cat sat mat",
+		"b": "cat sat rug",
+		"c": "cat lay bed",
+		"d": "dog ran far",
+	}
+	tests := []struct {
+		name    string
+		opts    []Option
+		id      string
+		k       int
+		wantIDs []string
+		wantErr error
+	}{
+		{
+			name:    "all candidates",
+			id:      "a",
+			wantIDs: []string{"b", "c"},
+		},
+		{
+			name:    "top k",
+			id:      "a",
+			k:       1,
+			wantIDs: []string{"b"},
+		},
+		{
+			name:    "highest weighted term only",
+			opts:    []Option{WithMostSimilarTerms(1)},
+			id:      "a",
+			wantIDs: []string{},
+		},
+		{
+			name:    "sparse comparator",
+			opts:    []Option{WithCompareMode(CompareTermFrequencyInverseDocumentFrequency), WithSparseComparator(SparseCosineComparator)},
+			id:      "a",
+			wantIDs: []string{"b", "c"},
+		},
+		{
+			name:    "term frequency inverse document frequency",
+			opts:    []Option{WithCompareMode(CompareTermFrequencyInverseDocumentFrequency)},
+			id:      "a",
+			wantIDs: []string{"b", "c"},
+		},
+		{
+			name:    "unknown document",
+			id:      "e",
+			wantErr: ErrDocumentNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(tt.opts...)
+			for id, document := range documents {
+				if err := i.AddDocumentWithID(id, document); err != nil {
+					t.Fatalf("AddDocumentWithID() error = %v", err)
+				}
+			}
+
+			got, err := i.MostSimilar(tt.id, tt.k)
+			if err != tt.wantErr {
+				t.Fatalf("MostSimilar() error = %v, want %v", err, tt.wantErr)
+			}
+
+			if len(got) != len(tt.wantIDs) {
+				t.Fatalf("MostSimilar() = %v, want %v", got, tt.wantIDs)
+			}
+
+			for index, result := range got {
+				if result.ID != tt.wantIDs[index] {
+					t.Errorf("MostSimilar()[%d] = %v, want %v", index, result.ID, tt.wantIDs[index])
+				}
+
+				want, _ := i.CompareByID(tt.id, result.ID)
+				if math.Abs(result.Score-want) > 1e-12 {
+					t.Errorf("MostSimilar()[%d].Score = %v, want %v", index, result.Score, want)
+				}
+			}
+		})
+	}
+}
@@ -2,24 +2,239 @@ package go_tf_idf
 
 import "math"
 
+// The comparators below return a similarity, higher meaning more similar. The
+// shorter of two vectors of different length is padded with zeros, and any
+// comparison involving a zero vector returns 0.
+
 func CosineComparator(vec1, vec2 []float64) float64 {
-	// Instead of padding with 0s we trim
-	min := int(math.Min(float64(len(vec1)), float64(len(vec2))))
+	vec1, vec2 = padVectors(vec1, vec2)
 	dot := float64(0)
-	for i := 0; i < min; i++ {
+	for i := range vec1 {
 		dot += vec1[i] * vec2[i]
 	}
 
-	magnitude := func(vec []float64) float64 {
-		sum := float64(0)
-		for _, xi := range vec {
-			sum += xi * xi
+	m1 := magnitude(vec1)
+	m2 := magnitude(vec2)
+	if m1 == 0 || m2 == 0 {
+		return 0
+	}
+
+	return dot / (m1 * m2)
+}
+
+// JaccardComparator returns the number of terms non-zero in both vectors
+// divided by the number of terms non-zero in either.
+func JaccardComparator(vec1, vec2 []float64) float64 {
+	vec1, vec2 = padVectors(vec1, vec2)
+	if isZeroVector(vec1) || isZeroVector(vec2) {
+		return 0
+	}
+
+	intersection, union := 0, 0
+	for i := range vec1 {
+		if vec1[i] != 0 && vec2[i] != 0 {
+			intersection++
+		}
+		if vec1[i] != 0 || vec2[i] != 0 {
+			union++
 		}
-		return math.Sqrt(sum)
 	}
 
-	m1 := magnitude(vec1)
-	m2 := magnitude(vec2)
-	alpha := dot / (m1 * m2)
-	return alpha
+	return float64(intersection) / float64(union)
+}
+
+// WeightedJaccardComparator returns the sum of the element-wise minimums
+// divided by the sum of the element-wise maximums of non-negative vectors.
+func WeightedJaccardComparator(vec1, vec2 []float64) float64 {
+	vec1, vec2 = padVectors(vec1, vec2)
+	if isZeroVector(vec1) || isZeroVector(vec2) {
+		return 0
+	}
+
+	min, max := float64(0), float64(0)
+	for i := range vec1 {
+		min += math.Min(vec1[i], vec2[i])
+		max += math.Max(vec1[i], vec2[i])
+	}
+
+	return min / max
+}
+
+// DiceComparator returns twice the sum of the element-wise minimums divided by
+// the summed totals of non-negative vectors. For vectors of zeros and ones it
+// is the Sørensen–Dice coefficient of the terms they contain.
+func DiceComparator(vec1, vec2 []float64) float64 {
+	vec1, vec2 = padVectors(vec1, vec2)
+	if isZeroVector(vec1) || isZeroVector(vec2) {
+		return 0
+	}
+
+	min, total := float64(0), float64(0)
+	for i := range vec1 {
+		min += math.Min(vec1[i], vec2[i])
+		total += vec1[i] + vec2[i]
+	}
+
+	return 2 * min / total
+}
+
+// EuclideanComparator converts the Euclidean distance d of the vectors into a
+// similarity of 1 / (1 + d).
+func EuclideanComparator(vec1, vec2 []float64) float64 {
+	vec1, vec2 = padVectors(vec1, vec2)
+	if isZeroVector(vec1) || isZeroVector(vec2) {
+		return 0
+	}
+
+	sum := float64(0)
+	for i := range vec1 {
+		sum += (vec1[i] - vec2[i]) * (vec1[i] - vec2[i])
+	}
+
+	return 1 / (1 + math.Sqrt(sum))
+}
+
+// ManhattanComparator converts the Manhattan distance d of the vectors into a
+// similarity of 1 / (1 + d).
+func ManhattanComparator(vec1, vec2 []float64) float64 {
+	vec1, vec2 = padVectors(vec1, vec2)
+	if isZeroVector(vec1) || isZeroVector(vec2) {
+		return 0
+	}
+
+	sum := float64(0)
+	for i := range vec1 {
+		sum += math.Abs(vec1[i] - vec2[i])
+	}
+
+	return 1 / (1 + sum)
+}
+
+// PearsonComparator returns the Pearson correlation coefficient of the
+// vectors, between -1 and 1, or 0 if either of them is constant.
+func PearsonComparator(vec1, vec2 []float64) float64 {
+	vec1, vec2 = padVectors(vec1, vec2)
+	if len(vec1) == 0 {
+		return 0
+	}
+
+	mean1, mean2 := mean(vec1), mean(vec2)
+	covariance, variance1, variance2 := float64(0), float64(0), float64(0)
+	for i := range vec1 {
+		d1, d2 := vec1[i]-mean1, vec2[i]-mean2
+		covariance += d1 * d2
+		variance1 += d1 * d1
+		variance2 += d2 * d2
+	}
+
+	if variance1 == 0 || variance2 == 0 {
+		return 0
+	}
+
+	return covariance / math.Sqrt(variance1*variance2)
+}
+
+// HellingerComparator normalizes non-negative vectors into probability
+// distributions and returns one minus their Hellinger distance.
+func HellingerComparator(vec1, vec2 []float64) float64 {
+	p, q := padVectors(vec1, vec2)
+	p, q = probabilities(p), probabilities(q)
+	if p == nil || q == nil {
+		return 0
+	}
+
+	coefficient := float64(0)
+	for i := range p {
+		coefficient += math.Sqrt(p[i] * q[i])
+	}
+
+	return 1 - math.Sqrt(math.Max(0, 1-coefficient))
+}
+
+// JensenShannonComparator normalizes non-negative vectors into probability
+// distributions and returns one minus their Jensen-Shannon divergence in
+// bits, which lies between 0 and 1.
+func JensenShannonComparator(vec1, vec2 []float64) float64 {
+	p, q := padVectors(vec1, vec2)
+	p, q = probabilities(p), probabilities(q)
+	if p == nil || q == nil {
+		return 0
+	}
+
+	divergence := float64(0)
+	for i := range p {
+		m := (p[i] + q[i]) / 2
+		if p[i] > 0 {
+			divergence += p[i] * math.Log2(p[i]/m) / 2
+		}
+		if q[i] > 0 {
+			divergence += q[i] * math.Log2(q[i]/m) / 2
+		}
+	}
+
+	return 1 - divergence
+}
+
+// padVectors pads the shorter of the vectors with zeros to the length of the
+// longer one.
+func padVectors(vec1, vec2 []float64) ([]float64, []float64) {
+	pad := func(vec []float64, length int) []float64 {
+		padded := make([]float64, length)
+		copy(padded, vec)
+		return padded
+	}
+
+	switch {
+	case len(vec1) < len(vec2):
+		vec1 = pad(vec1, len(vec2))
+	case len(vec2) < len(vec1):
+		vec2 = pad(vec2, len(vec1))
+	}
+
+	return vec1, vec2
+}
+
+func isZeroVector(vec []float64) bool {
+	for _, xi := range vec {
+		if xi != 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+func magnitude(vec []float64) float64 {
+	sum := float64(0)
+	for _, xi := range vec {
+		sum += xi * xi
+	}
+	return math.Sqrt(sum)
+}
+
+func mean(vec []float64) float64 {
+	sum := float64(0)
+	for _, xi := range vec {
+		sum += xi
+	}
+	return sum / float64(len(vec))
+}
+
+// probabilities scales vec to sum to one, or returns nil if it sums to zero.
+func probabilities(vec []float64) []float64 {
+	sum := float64(0)
+	for _, xi := range vec {
+		sum += xi
+	}
+
+	if sum == 0 {
+		return nil
+	}
+
+	scaled := make([]float64, len(vec))
+	for i, xi := range vec {
+		scaled[i] = xi / sum
+	}
+
+	return scaled
 }
@@ -1,6 +1,7 @@
 package go_tf_idf
 
 import (
+	"math"
 	"testing"
 )
 
@@ -39,3 +40,81 @@ func TestCosineComparator(t *testing.T) {
 		})
 	}
 }
+
+func TestComparators(t *testing.T) {
+	comparators := map[string]Comparator{
+		"cosine":           CosineComparator,
+		"jaccard":          JaccardComparator,
+		"weighted jaccard": WeightedJaccardComparator,
+		"dice":             DiceComparator,
+		"euclidean":        EuclideanComparator,
+		"manhattan":        ManhattanComparator,
+		"pearson":          PearsonComparator,
+		"hellinger":        HellingerComparator,
+		"jensen-shannon":   JensenShannonComparator,
+	}
+	tests := []struct {
+		name string
+		vec1 []float64
+		vec2 []float64
+		want map[string]float64
+	}{
+		{
+			name: "partial overlap",
+			vec1: []float64{1, 0, 1},
+			vec2: []float64{1, 1, 0},
+			want: map[string]float64{
+				"cosine":           0.5,
+				"jaccard":          1.0 / 3,
+				"weighted jaccard": 1.0 / 3,
+				"dice":             0.5,
+				"euclidean":        math.Sqrt2 - 1,
+				"manhattan":        1.0 / 3,
+				"pearson":          -0.5,
+				"hellinger":        1 - math.Sqrt(0.5),
+				"jensen-shannon":   0.5,
+			},
+		},
+		{
+			name: "padded to equal vectors",
+			vec1: []float64{2, 0},
+			vec2: []float64{2},
+			want: map[string]float64{
+				"cosine":           1,
+				"jaccard":          1,
+				"weighted jaccard": 1,
+				"dice":             1,
+				"euclidean":        1,
+				"manhattan":        1,
+				"pearson":          1,
+				"hellinger":        1,
+				"jensen-shannon":   1,
+			},
+		},
+		{
+			name: "zero vector",
+			vec1: []float64{0, 0},
+			vec2: []float64{0, 0},
+			want: map[string]float64{
+				"cosine":           0,
+				"jaccard":          0,
+				"weighted jaccard": 0,
+				"dice":             0,
+				"euclidean":        0,
+				"manhattan":        0,
+				"pearson":          0,
+				"hellinger":        0,
+				"jensen-shannon":   0,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, want := range tt.want {
+				if got := comparators[name](tt.vec1, tt.vec2); math.Abs(got-want) > 1e-12 {
+					t.Errorf("%s comparator = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
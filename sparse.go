@@ -0,0 +1,160 @@
+package go_tf_idf
+
+import (
+	"math"
+	"sort"
+)
+
+// SparseVector holds the non-zero entries of a vector indexed by the
+// vocabulary of a TfIdf. Indices are in ascending order and Values[k] is the
+// entry at Indices[k].
+type SparseVector struct {
+	Indices []int
+	Values  []float64
+}
+
+// NewSparseVector returns the non-zero entries of dense.
+func NewSparseVector(dense []float64) SparseVector {
+	vector := SparseVector{Indices: make([]int, 0), Values: make([]float64, 0)}
+	for index, value := range dense {
+		if value != 0 {
+			vector.Indices = append(vector.Indices, index)
+			vector.Values = append(vector.Values, value)
+		}
+	}
+
+	return vector
+}
+
+// Dense returns the vector as a slice of length entries. Entries at indices
+// beyond length are dropped.
+func (v SparseVector) Dense(length int) []float64 {
+	dense := make([]float64, length)
+	for k, index := range v.Indices {
+		if index < length {
+			dense[index] = v.Values[k]
+		}
+	}
+
+	return dense
+}
+
+func (v SparseVector) Dot(other SparseVector) float64 {
+	dot := float64(0)
+	for a, b := 0, 0; a < len(v.Indices) && b < len(other.Indices); {
+		switch {
+		case v.Indices[a] < other.Indices[b]:
+			a++
+		case v.Indices[a] > other.Indices[b]:
+			b++
+		default:
+			dot += v.Values[a] * other.Values[b]
+			a++
+			b++
+		}
+	}
+
+	return dot
+}
+
+func (v SparseVector) Norm() float64 {
+	sum := float64(0)
+	for _, value := range v.Values {
+		sum += value * value
+	}
+
+	return math.Sqrt(sum)
+}
+
+// Cosine returns the cosine similarity of the vectors, or 0 if either of them
+// is the zero vector.
+func (v SparseVector) Cosine(other SparseVector) float64 {
+	norms := v.Norm() * other.Norm()
+	if norms == 0 {
+		return 0
+	}
+
+	return v.Dot(other) / norms
+}
+
+type SparseComparator func(vector1, vector2 SparseVector) float64
+
+func SparseCosineComparator(vector1, vector2 SparseVector) float64 {
+	return vector1.Cosine(vector2)
+}
+
+// WithSparseComparator makes Compare build sparse vectors for the configured
+// CompareMode and compare them with comparator instead of the Comparator.
+func WithSparseComparator(comparator SparseComparator) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.sparseComparator = comparator
+	}
+}
+
+// SparseVectorForDocument is the sparse form of
+// TermFrequencyInverseDocumentFrequencyForDocument.
+func (i *TfIdf) SparseVectorForDocument(document string) SparseVector {
+	return i.SparseVectorForDocumentByID(md5Hash(document))
+}
+
+func (i *TfIdf) SparseVectorForDocumentByID(id string) SparseVector {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	doc, ok := i.Documents[id]
+	if !ok {
+		return i.sparseVector(nil)
+	}
+
+	return i.sparseVector(i.termWeights(doc))
+}
+
+// SparseVectorForQuery weighs the terms of query as if it were a document of
+// the corpus, without adding it. Terms not contained in the corpus are left
+// out, including by the normalization of a Weighting, so that with cosine
+// normalization the vector has unit length.
+func (i *TfIdf) SparseVectorForQuery(query string) SparseVector {
+	doc, err := i.newQuery(query)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if err != nil {
+		return i.sparseVector(nil)
+	}
+
+	return i.sparseVector(i.termWeights(i.vocabularyDocument(doc)))
+}
+
+// sparseVector places weights at the index of their term, leaving out zero
+// weights and terms outside the vocabulary.
+func (i *TfIdf) sparseVector(weights map[string]float64) SparseVector {
+	indices := make([]int, 0, len(weights))
+	byIndex := make(map[int]float64, len(weights))
+	for term, weight := range weights {
+		index, ok := i.termToIndex[term]
+		if !ok || weight == 0 {
+			continue
+		}
+
+		indices = append(indices, index)
+		byIndex[index] = weight
+	}
+	sort.Ints(indices)
+
+	vector := SparseVector{Indices: indices, Values: make([]float64, len(indices))}
+	for k, index := range indices {
+		vector.Values[k] = byIndex[index]
+	}
+
+	return vector
+}
+
+func termFrequencies(doc Document) map[string]float64 {
+	frequencies := make(map[string]float64, len(doc.UniqueTokens))
+	for _, term := range doc.UniqueTokens {
+		frequencies[term] = doc.TermFrequency(term)
+	}
+
+	return frequencies
+}
@@ -0,0 +1,110 @@
+package go_tf_idf
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSparseVector(t *testing.T) {
+	tests := []struct {
+		name       string
+		v1         SparseVector
+		v2         SparseVector
+		wantDot    float64
+		wantCosine float64
+	}{
+		{
+			name:       "overlapping",
+			v1:         SparseVector{Indices: []int{0, 2, 5}, Values: []float64{1, 2, 3}},
+			v2:         SparseVector{Indices: []int{2, 3, 5}, Values: []float64{4, 5, 6}},
+			wantDot:    26,
+			wantCosine: 26 / (math.Sqrt(14) * math.Sqrt(77)),
+		},
+		{
+			name:       "disjoint",
+			v1:         SparseVector{Indices: []int{0}, Values: []float64{1}},
+			v2:         SparseVector{Indices: []int{1}, Values: []float64{1}},
+			wantDot:    0,
+			wantCosine: 0,
+		},
+		{
+			name:       "zero vector",
+			v1:         SparseVector{},
+			v2:         SparseVector{Indices: []int{1}, Values: []float64{1}},
+			wantDot:    0,
+			wantCosine: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.v1.Dot(tt.v2); got != tt.wantDot {
+				t.Errorf("Dot() = %v, want %v", got, tt.wantDot)
+			}
+			if got := tt.v1.Cosine(tt.v2); math.Abs(got-tt.wantCosine) > 1e-12 {
+				t.Errorf("Cosine() = %v, want %v", got, tt.wantCosine)
+			}
+		})
+	}
+}
+
+func TestNewSparseVector(t *testing.T) {
+	dense := []float64{0, 1.5, 0, 0, 2}
+	vector := NewSparseVector(dense)
+	if want := (SparseVector{Indices: []int{1, 4}, Values: []float64{1.5, 2}}); !reflect.DeepEqual(vector, want) {
+		t.Errorf("NewSparseVector() = %v, want %v", vector, want)
+	}
+
+	if got := vector.Dense(len(dense)); !reflect.DeepEqual(got, dense) {
+		t.Errorf("Dense() = %v, want %v", got, dense)
+	}
+
+	if got, want := vector.Norm(), 2.5; got != want {
+		t.Errorf("Norm() = %v, want %v", got, want)
+	}
+}
+
+func TestTfIdf_SparseVectorForDocument(t *testing.T) {
+	documents := []string{"a cat sat on a mat", "a dog sat on a log", "cats and dogs"}
+	i := New(WithDocuments(documents))
+
+	for _, document := range documents {
+		dense := i.TermFrequencyInverseDocumentFrequencyForDocument(document)
+		if got, want := i.SparseVectorForDocument(document), NewSparseVector(dense); !reflect.DeepEqual(got, want) {
+			t.Errorf("SparseVectorForDocument(%q) = %v, want %v", document, got, want)
+		}
+	}
+
+	query := i.SparseVectorForQuery("cat on a unicorn")
+	if got, want := query.Indices, []int{0, 1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("SparseVectorForQuery() indices = %v, want %v", got, want)
+	}
+}
+
+func TestTfIdf_SparseVectorForQueryNormalization(t *testing.T) {
+	i := New(WithWeighting("nsc"), WithDocuments([]string{"a cat sat on a mat", "a dog sat on a log"}))
+
+	query := i.SparseVectorForQuery("a cat zzz qqq")
+	if got := query.Norm(); math.Abs(got-1) > 1e-12 {
+		t.Errorf("SparseVectorForQuery().Norm() = %v, want 1", got)
+	}
+
+	i.Fit()
+	if want, _ := i.TransformSparse("a cat zzz qqq"); !reflect.DeepEqual(query, want) {
+		t.Errorf("SparseVectorForQuery() = %v, want %v", query, want)
+	}
+}
+
+func TestTfIdf_CompareWithSparseComparator(t *testing.T) {
+	documents := []string{"a cat sat on a mat", "a dog sat on a log"}
+	for _, mode := range []CompareMode{CompareTermFrequency, CompareTermFrequencyInverseDocumentFrequency} {
+		dense := New(WithCompareMode(mode), WithDocuments(documents))
+		sparse := New(WithCompareMode(mode), WithSparseComparator(SparseCosineComparator), WithDocuments(documents))
+
+		want, _ := dense.Compare(documents[0], documents[1])
+		got, err := sparse.Compare(documents[0], documents[1])
+		if err != nil || math.Abs(got-want) > 1e-12 {
+			t.Errorf("Compare() = %v, %v, want %v", got, err, want)
+		}
+	}
+}
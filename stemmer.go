@@ -0,0 +1,245 @@
+package go_tf_idf
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var ErrUnknownLanguage = errors.New("unknown language")
+
+// Stemmer reduces a lowercase word to its stem.
+type Stemmer func(word string) string
+
+// Stemmers maps ISO 639-1 language codes to the Snowball stemmer of the
+// language.
+var Stemmers = map[string]Stemmer{
+	"da": DanishStemmer,
+	"de": GermanStemmer,
+	"en": EnglishStemmer,
+	"es": SpanishStemmer,
+	"fr": FrenchStemmer,
+	"it": ItalianStemmer,
+	"nl": DutchStemmer,
+	"pt": PortugueseStemmer,
+	"ru": RussianStemmer,
+	"sv": SwedishStemmer,
+}
+
+// WithStemmer reduces every term to its stem before it is counted. Stemming
+// happens after stop word removal, so stop words are matched against the
+// unstemmed tokens. Terms passed to lookups such as InverseDocumentFrequency
+// or Postings must be stemmed by the caller.
+func WithStemmer(stemmer Stemmer) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.stemmer = stemmer
+	}
+}
+
+// WithStemmerForLanguage uses the stemmer registered for lang in Stemmers. The
+// option is ignored if there is none; languages from configuration or user
+// input should be checked with LookupStemmer and passed to WithStemmer.
+func WithStemmerForLanguage(lang string) Option {
+	return func(tfIdf *TfIdf) {
+		if stemmer, ok := Stemmers[lang]; ok {
+			tfIdf.stemmer = stemmer
+		}
+	}
+}
+
+// LookupStemmer returns the stemmer registered for lang in Stemmers, or
+// ErrUnknownLanguage if there is none.
+func LookupStemmer(lang string) (Stemmer, error) {
+	stemmer, ok := Stemmers[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w %q: no stemmer", ErrUnknownLanguage, lang)
+	}
+
+	return stemmer, nil
+}
+
+// StemFilter returns a TokenFilter replacing every token by its stem, for use
+// in custom Analyzer pipelines.
+func StemFilter(stemmer Stemmer) TokenFilter {
+	return func(tokens []string) []string {
+		for index, token := range tokens {
+			tokens[index] = stemmer(token)
+		}
+
+		return tokens
+	}
+}
+
+// word is the working state of the Snowball stemmers: the runes of the word
+// and the start of its R1, R2 and RV regions. As in Snowball, region marks are
+// not adjusted when the word shrinks.
+type word struct {
+	runes      []rune
+	p1, p2, rv int
+}
+
+func newWord(s string) *word {
+	runes := []rune(s)
+	return &word{runes: runes, p1: len(runes), p2: len(runes), rv: len(runes)}
+}
+
+func (w *word) String() string {
+	return string(w.runes)
+}
+
+// markRegions sets R1 to the region after the first non-vowel following a
+// vowel, and R2 to the same region within R1.
+func (w *word) markRegions(isVowel func(rune) bool) {
+	w.p1 = w.regionAfter(0, isVowel)
+	w.p2 = w.regionAfter(w.p1, isVowel)
+}
+
+func (w *word) regionAfter(start int, isVowel func(rune) bool) int {
+	for index := start + 1; index < len(w.runes); index++ {
+		if !isVowel(w.runes[index]) && isVowel(w.runes[index-1]) {
+			return index + 1
+		}
+	}
+
+	return len(w.runes)
+}
+
+// markRV sets RV as defined for the Romance languages: after the next vowel if
+// the second letter is a consonant, after the next consonant if the first two
+// letters are vowels, and after the third letter otherwise.
+func (w *word) markRV(isVowel func(rune) bool) {
+	w.rv = len(w.runes)
+	if len(w.runes) < 2 {
+		return
+	}
+
+	switch {
+	case !isVowel(w.runes[1]):
+		for index := 2; index < len(w.runes); index++ {
+			if isVowel(w.runes[index]) {
+				w.rv = index + 1
+				return
+			}
+		}
+	case isVowel(w.runes[0]):
+		for index := 2; index < len(w.runes); index++ {
+			if !isVowel(w.runes[index]) {
+				w.rv = index + 1
+				return
+			}
+		}
+	default:
+		w.rv = 3
+	}
+
+	if w.rv > len(w.runes) {
+		w.rv = len(w.runes)
+	}
+}
+
+func (w *word) hasSuffix(suffix string) bool {
+	return w.suffixStart(suffix) >= 0
+}
+
+// suffixStart returns the index at which suffix starts in the word, or -1 if
+// the word does not end with suffix.
+func (w *word) suffixStart(suffix string) int {
+	s := []rune(suffix)
+	start := len(w.runes) - len(s)
+	if start < 0 {
+		return -1
+	}
+
+	for index, r := range s {
+		if w.runes[start+index] != r {
+			return -1
+		}
+	}
+
+	return start
+}
+
+// findSuffix returns the longest of suffixes that the word ends with and that
+// starts at or after min, or "" if there is none. suffixes must be ordered by
+// decreasing length.
+func (w *word) findSuffix(suffixes []string, min int) string {
+	for _, suffix := range suffixes {
+		if start := w.suffixStart(suffix); start >= min {
+			return suffix
+		}
+	}
+
+	return ""
+}
+
+// in reports whether suffix starts at or after the region start.
+func (w *word) in(suffix string, region int) bool {
+	start := w.suffixStart(suffix)
+	return start >= 0 && start >= region
+}
+
+func (w *word) trim(suffix string) {
+	w.runes = w.runes[:len(w.runes)-len([]rune(suffix))]
+}
+
+func (w *word) replace(suffix, replacement string) {
+	w.trim(suffix)
+	w.runes = append(w.runes, []rune(replacement)...)
+}
+
+// trimInR2 trims the first of candidates the word ends with if it lies in R2,
+// and returns the trimmed suffix or "" if nothing was trimmed.
+func (w *word) trimInR2(candidates ...string) string {
+	for _, candidate := range candidates {
+		if !w.hasSuffix(candidate) {
+			continue
+		}
+
+		if w.in(candidate, w.p2) {
+			w.trim(candidate)
+			return candidate
+		}
+		return ""
+	}
+
+	return ""
+}
+
+// at returns the rune at index from the end of the word, 1 being the last, or
+// 0 if the word is too short.
+func (w *word) at(fromEnd int) rune {
+	if fromEnd > len(w.runes) || fromEnd <= 0 {
+		return 0
+	}
+
+	return w.runes[len(w.runes)-fromEnd]
+}
+
+// suffixes orders words by decreasing rune count so that findSuffix returns
+// the longest match.
+func suffixes(words ...string) []string {
+	sort.SliceStable(words, func(a, b int) bool {
+		return len([]rune(words[a])) > len([]rune(words[b]))
+	})
+
+	return words
+}
+
+func vowels(set string) func(rune) bool {
+	lookup := make(map[rune]bool, len(set))
+	for _, r := range set {
+		lookup[r] = true
+	}
+
+	return func(r rune) bool {
+		return lookup[r]
+	}
+}
+
+func replaceRunes(runes []rune, replacements map[rune]rune) {
+	for index, r := range runes {
+		if replacement, ok := replacements[r]; ok {
+			runes[index] = replacement
+		}
+	}
+}
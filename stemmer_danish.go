@@ -0,0 +1,64 @@
+package go_tf_idf
+
+import "strings"
+
+var (
+	danishVowel = vowels("aeiouyæåø")
+
+	danishStep1 = suffixes(
+		"hed", "ethed", "ered", "e", "erede", "ende", "erende", "ene", "erne",
+		"ere", "en", "heden", "eren", "er", "heder", "erer", "heds", "es",
+		"endes", "erendes", "enes", "ernes", "eres", "ens", "hedens", "erens",
+		"ers", "ets", "erets", "et", "eret", "s",
+	)
+	danishStep2 = suffixes("gd", "dt", "gt", "kt")
+	danishStep3 = suffixes("ig", "lig", "elig", "els", "løst")
+)
+
+// DanishStemmer implements the Snowball Danish stemmer.
+func DanishStemmer(s string) string {
+	w := newWord(s)
+	w.markRegions(danishVowel)
+	if w.p1 < 3 {
+		w.p1 = 3
+	}
+
+	switch suffix := w.findSuffix(danishStep1, w.p1); suffix {
+	case "":
+	case "s":
+		if strings.ContainsRune("abcdfghjklmnoprtvyzå", w.at(2)) {
+			w.trim(suffix)
+		}
+	default:
+		w.trim(suffix)
+	}
+
+	danishConsonantPair(w)
+
+	if w.hasSuffix("igst") {
+		w.trim("st")
+	}
+
+	switch suffix := w.findSuffix(danishStep3, w.p1); suffix {
+	case "ig", "lig", "elig", "els":
+		w.trim(suffix)
+		danishConsonantPair(w)
+	case "løst":
+		w.replace(suffix, "løs")
+	}
+
+	if n := len(w.runes); n-1 >= w.p1 && n >= 2 {
+		last := w.runes[n-1]
+		if !danishVowel(last) && w.runes[n-2] == last {
+			w.runes = w.runes[:n-1]
+		}
+	}
+
+	return w.String()
+}
+
+func danishConsonantPair(w *word) {
+	if w.findSuffix(danishStep2, w.p1) != "" {
+		w.runes = w.runes[:len(w.runes)-1]
+	}
+}
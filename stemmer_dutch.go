@@ -0,0 +1,131 @@
+package go_tf_idf
+
+var (
+	dutchVowel = vowels("aeiouyè")
+
+	dutchStep1  = suffixes("heden", "en", "ene", "s", "se")
+	dutchStep3b = suffixes("end", "ing", "ig", "lijk", "baar", "bar")
+)
+
+// DutchStemmer implements the Snowball Dutch stemmer.
+func DutchStemmer(s string) string {
+	w := newWord(s)
+	replaceRunes(w.runes, map[rune]rune{
+		'ä': 'a', 'á': 'a', 'ë': 'e', 'é': 'e', 'ï': 'i',
+		'í': 'i', 'ö': 'o', 'ó': 'o', 'ü': 'u', 'ú': 'u',
+	})
+
+	for index, r := range w.runes {
+		switch {
+		case r == 'y' && (index == 0 || dutchVowel(w.runes[index-1])):
+			w.runes[index] = 'Y'
+		case r == 'i' && index > 0 && index < len(w.runes)-1 && dutchVowel(w.runes[index-1]) && dutchVowel(w.runes[index+1]):
+			w.runes[index] = 'I'
+		}
+	}
+
+	w.markRegions(dutchVowel)
+	if w.p1 < 3 {
+		w.p1 = 3
+	}
+
+	switch suffix := w.findSuffix(dutchStep1, 0); suffix {
+	case "heden":
+		if w.in(suffix, w.p1) {
+			w.replace(suffix, "heid")
+		}
+	case "en", "ene":
+		dutchEnEnding(w, suffix)
+	case "s", "se":
+		before := w.at(len([]rune(suffix)) + 1)
+		if w.in(suffix, w.p1) && before != 0 && !dutchVowel(before) && before != 'j' {
+			w.trim(suffix)
+		}
+	}
+
+	eFound := dutchEEnding(w)
+
+	if w.in("heid", w.p2) && w.at(5) != 'c' {
+		w.trim("heid")
+		if w.hasSuffix("en") {
+			dutchEnEnding(w, "en")
+		}
+	}
+
+	switch suffix := w.findSuffix(dutchStep3b, 0); suffix {
+	case "end", "ing":
+		if w.in(suffix, w.p2) {
+			w.trim(suffix)
+			if w.in("ig", w.p2) && w.at(3) != 'e' {
+				w.trim("ig")
+			} else {
+				dutchUndouble(w)
+			}
+		}
+	case "ig":
+		if w.in(suffix, w.p2) && w.at(3) != 'e' {
+			w.trim(suffix)
+		}
+	case "lijk":
+		if w.in(suffix, w.p2) {
+			w.trim(suffix)
+			dutchEEnding(w)
+		}
+	case "baar":
+		if w.in(suffix, w.p2) {
+			w.trim(suffix)
+		}
+	case "bar":
+		if w.in(suffix, w.p2) && eFound {
+			w.trim(suffix)
+		}
+	}
+
+	if n := len(w.runes); n >= 4 {
+		last := w.runes[n-1]
+		if !dutchVowel(last) && last != 'I' && !dutchVowel(w.runes[n-4]) {
+			switch string(w.runes[n-3 : n-1]) {
+			case "aa", "ee", "oo", "uu":
+				w.runes = append(w.runes[:n-2], last)
+			}
+		}
+	}
+
+	replaceRunes(w.runes, map[rune]rune{'I': 'i', 'Y': 'y'})
+	return w.String()
+}
+
+// dutchEnEnding deletes suffix if it is in R1, preceded by a non-vowel and
+// not preceded by gem, and undoubles the ending.
+func dutchEnEnding(w *word, suffix string) {
+	start := w.suffixStart(suffix)
+	if start < w.p1 || start == 0 || dutchVowel(w.runes[start-1]) {
+		return
+	}
+
+	if start >= 3 && string(w.runes[start-3:start]) == "gem" {
+		return
+	}
+
+	w.trim(suffix)
+	dutchUndouble(w)
+}
+
+// dutchEEnding deletes a final e in R1 preceded by a non-vowel, undoubles the
+// ending and reports whether the e was deleted.
+func dutchEEnding(w *word) bool {
+	before := w.at(2)
+	if !w.in("e", w.p1) || before == 0 || dutchVowel(before) {
+		return false
+	}
+
+	w.trim("e")
+	dutchUndouble(w)
+	return true
+}
+
+func dutchUndouble(w *word) {
+	if w.hasSuffix("kk") || w.hasSuffix("dd") || w.hasSuffix("tt") {
+		w.runes = w.runes[:len(w.runes)-1]
+	}
+}
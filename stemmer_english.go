@@ -0,0 +1,278 @@
+package go_tf_idf
+
+import "strings"
+
+var (
+	englishVowel = vowels("aeiouy")
+
+	englishExceptions = map[string]string{
+		"skis":   "ski",
+		"skies":  "sky",
+		"dying":  "die",
+		"lying":  "lie",
+		"tying":  "tie",
+		"idly":   "idl",
+		"gently": "gentl",
+		"ugly":   "ugli",
+		"early":  "earli",
+		"only":   "onli",
+		"singly": "singl",
+		"sky":    "sky",
+		"news":   "news",
+		"howe":   "howe",
+		"atlas":  "atlas",
+		"cosmos": "cosmos",
+		"bias":   "bias",
+		"andes":  "andes",
+	}
+
+	englishInvariantAfterStep1a = map[string]bool{
+		"inning":  true,
+		"outing":  true,
+		"canning": true,
+		"herring": true,
+		"earring": true,
+		"proceed": true,
+		"exceed":  true,
+		"succeed": true,
+	}
+
+	englishStep0  = suffixes("'s'", "'s", "'")
+	englishStep1a = suffixes("sses", "ied", "ies", "us", "ss", "s")
+	englishStep1b = suffixes("eed", "eedly", "ed", "edly", "ing", "ingly")
+	englishStep2  = suffixes(
+		"tional", "enci", "anci", "abli", "entli", "izer", "ization",
+		"ational", "ation", "ator", "alism", "aliti", "alli", "fulness",
+		"ousli", "ousness", "iveness", "iviti", "biliti", "bli", "ogi",
+		"fulli", "lessli", "li",
+	)
+	englishStep3 = suffixes("tional", "ational", "alize", "icate", "iciti", "ical", "ful", "ness", "ative")
+	englishStep4 = suffixes(
+		"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement",
+		"ment", "ent", "ism", "ate", "iti", "ous", "ive", "ize", "ion",
+	)
+
+	englishStep2Replacements = map[string]string{
+		"tional": "tion", "enci": "ence", "anci": "ance", "abli": "able",
+		"entli": "ent", "izer": "ize", "ization": "ize", "ational": "ate",
+		"ation": "ate", "ator": "ate", "alism": "al", "aliti": "al",
+		"alli": "al", "fulness": "ful", "ousli": "ous", "ousness": "ous",
+		"iveness": "ive", "iviti": "ive", "biliti": "ble", "bli": "ble",
+		"ogi": "og", "fulli": "ful", "lessli": "less", "li": "",
+	}
+
+	englishStep3Replacements = map[string]string{
+		"tional": "tion", "ational": "ate", "alize": "al", "icate": "ic",
+		"iciti": "ic", "ical": "ic", "ful": "", "ness": "", "ative": "",
+	}
+)
+
+// EnglishStemmer implements the Snowball English (Porter2) stemmer.
+func EnglishStemmer(s string) string {
+	if len([]rune(s)) <= 2 {
+		return s
+	}
+
+	s = strings.ReplaceAll(s, "’", "'")
+	if exception, ok := englishExceptions[s]; ok {
+		return exception
+	}
+
+	s = strings.TrimPrefix(s, "'")
+	w := newWord(s)
+	for index, r := range w.runes {
+		if r == 'y' && (index == 0 || englishVowel(w.runes[index-1])) {
+			w.runes[index] = 'Y'
+		}
+	}
+
+	w.markRegions(englishVowel)
+	for _, prefix := range []string{"gener", "commun", "arsen"} {
+		if strings.HasPrefix(s, prefix) {
+			w.p1 = len(prefix)
+			w.p2 = w.regionAfter(w.p1, englishVowel)
+		}
+	}
+
+	englishStep0Apply(w)
+	englishStep1aApply(w)
+	if englishInvariantAfterStep1a[w.String()] {
+		return w.String()
+	}
+
+	englishStep1bApply(w)
+	englishStep1cApply(w)
+	englishStep2Apply(w)
+	englishStep3Apply(w)
+	englishStep4Apply(w)
+	englishStep5Apply(w)
+
+	return strings.ReplaceAll(w.String(), "Y", "y")
+}
+
+func englishStep0Apply(w *word) {
+	if suffix := w.findSuffix(englishStep0, 0); suffix != "" {
+		w.trim(suffix)
+	}
+}
+
+func englishStep1aApply(w *word) {
+	switch suffix := w.findSuffix(englishStep1a, 0); suffix {
+	case "sses":
+		w.replace(suffix, "ss")
+	case "ied", "ies":
+		if len(w.runes) > 4 {
+			w.replace(suffix, "i")
+		} else {
+			w.replace(suffix, "ie")
+		}
+	case "s":
+		if len(w.runes) < 3 {
+			return
+		}
+
+		for _, r := range w.runes[:len(w.runes)-2] {
+			if englishVowel(r) {
+				w.trim(suffix)
+				return
+			}
+		}
+	}
+}
+
+func englishStep1bApply(w *word) {
+	suffix := w.findSuffix(englishStep1b, 0)
+	switch suffix {
+	case "":
+		return
+	case "eed", "eedly":
+		if w.in(suffix, w.p1) {
+			w.replace(suffix, "ee")
+		}
+		return
+	}
+
+	containsVowel := false
+	for _, r := range w.runes[:w.suffixStart(suffix)] {
+		if englishVowel(r) {
+			containsVowel = true
+			break
+		}
+	}
+	if !containsVowel {
+		return
+	}
+
+	w.trim(suffix)
+	switch {
+	case w.hasSuffix("at"), w.hasSuffix("bl"), w.hasSuffix("iz"):
+		w.runes = append(w.runes, 'e')
+	case englishEndsWithDouble(w):
+		w.runes = w.runes[:len(w.runes)-1]
+	case englishIsShortWord(w):
+		w.runes = append(w.runes, 'e')
+	}
+}
+
+func englishStep1cApply(w *word) {
+	last := w.at(1)
+	if (last == 'y' || last == 'Y') && len(w.runes) > 2 && !englishVowel(w.at(2)) {
+		w.runes[len(w.runes)-1] = 'i'
+	}
+}
+
+func englishStep2Apply(w *word) {
+	suffix := w.findSuffix(englishStep2, 0)
+	if suffix == "" || !w.in(suffix, w.p1) {
+		return
+	}
+
+	switch suffix {
+	case "ogi":
+		if w.at(4) != 'l' {
+			return
+		}
+	case "li":
+		if !strings.ContainsRune("cdeghkmnrt", w.at(3)) {
+			return
+		}
+	}
+
+	w.replace(suffix, englishStep2Replacements[suffix])
+}
+
+func englishStep3Apply(w *word) {
+	suffix := w.findSuffix(englishStep3, 0)
+	if suffix == "" || !w.in(suffix, w.p1) {
+		return
+	}
+
+	if suffix == "ative" && !w.in(suffix, w.p2) {
+		return
+	}
+
+	w.replace(suffix, englishStep3Replacements[suffix])
+}
+
+func englishStep4Apply(w *word) {
+	suffix := w.findSuffix(englishStep4, 0)
+	if suffix == "" || !w.in(suffix, w.p2) {
+		return
+	}
+
+	if suffix == "ion" && w.at(4) != 's' && w.at(4) != 't' {
+		return
+	}
+
+	w.trim(suffix)
+}
+
+func englishStep5Apply(w *word) {
+	switch w.at(1) {
+	case 'e':
+		if w.in("e", w.p2) {
+			w.trim("e")
+			return
+		}
+
+		if w.in("e", w.p1) && !englishEndsWithShortSyllable(w.runes[:len(w.runes)-1]) {
+			w.trim("e")
+		}
+	case 'l':
+		if w.in("l", w.p2) && w.at(2) == 'l' {
+			w.trim("l")
+		}
+	}
+}
+
+func englishEndsWithDouble(w *word) bool {
+	for _, double := range []string{"bb", "dd", "ff", "gg", "mm", "nn", "pp", "rr", "tt"} {
+		if w.hasSuffix(double) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// englishEndsWithShortSyllable reports whether runes end with a non-vowel
+// other than w, x or Y preceded by a vowel preceded by a non-vowel, or are a
+// vowel followed by a non-vowel.
+func englishEndsWithShortSyllable(runes []rune) bool {
+	n := len(runes)
+	if n == 2 {
+		return englishVowel(runes[0]) && !englishVowel(runes[1])
+	}
+
+	if n < 3 {
+		return false
+	}
+
+	last := runes[n-1]
+	return !englishVowel(last) && last != 'w' && last != 'x' && last != 'Y' &&
+		englishVowel(runes[n-2]) && !englishVowel(runes[n-3])
+}
+
+func englishIsShortWord(w *word) bool {
+	return w.p1 >= len(w.runes) && englishEndsWithShortSyllable(w.runes)
+}
@@ -0,0 +1,299 @@
+package go_tf_idf
+
+import "strings"
+
+var (
+	frenchVowel = vowels("aeiouyâàëéêèïîôûù")
+
+	frenchStep1 = suffixes(
+		"ance", "iqUe", "isme", "able", "iste", "eux", "ances", "iqUes", "ismes",
+		"ables", "istes", "atrice", "ateur", "ation", "atrices", "ateurs",
+		"ations", "logie", "logies", "usion", "ution", "usions", "utions",
+		"ence", "ences", "ement", "ements", "ité", "ités", "if", "ive", "ifs",
+		"ives", "eaux", "aux", "euse", "euses", "issement", "issements",
+		"amment", "emment", "ment", "ments",
+	)
+	frenchStep2a = suffixes(
+		"îmes", "ît", "îtes", "i", "ie", "ies", "ir", "ira", "irai", "iraIent",
+		"irais", "irait", "iras", "irent", "irez", "iriez", "irions", "irons",
+		"iront", "is", "issaIent", "issais", "issait", "issant", "issante",
+		"issantes", "issants", "isse", "issent", "isses", "issez", "issiez",
+		"issions", "issons", "it",
+	)
+	frenchStep2b = suffixes(
+		"ions", "é", "ée", "ées", "és", "èrent", "er", "era", "erai", "eraIent",
+		"erais", "erait", "eras", "erez", "eriez", "erions", "erons", "eront",
+		"ez", "iez", "âmes", "ât", "âtes", "a", "ai", "aIent", "ais", "ait",
+		"ant", "ante", "antes", "ants", "as", "asse", "assent", "asses",
+		"assiez", "assions",
+	)
+	frenchStep4 = suffixes("ion", "ier", "ière", "Ier", "Ière", "e", "ë")
+
+	frenchEmentSuffixes = suffixes("iv", "eus", "abl", "iqU", "ièr", "Ièr")
+	frenchIteSuffixes   = suffixes("abil", "ic", "iv")
+)
+
+// FrenchStemmer implements the Snowball French stemmer.
+func FrenchStemmer(s string) string {
+	w := newWord(s)
+	for index, r := range w.runes {
+		previous := index > 0 && frenchVowel(w.runes[index-1])
+		next := index < len(w.runes)-1 && frenchVowel(w.runes[index+1])
+		switch {
+		case (r == 'u' || r == 'i') && previous && next:
+			w.runes[index] = r - 'a' + 'A'
+		case r == 'y' && (previous || next):
+			w.runes[index] = 'Y'
+		case r == 'u' && index > 0 && w.runes[index-1] == 'q':
+			w.runes[index] = 'U'
+		}
+	}
+
+	frenchMarkRV(w)
+	w.markRegions(frenchVowel)
+
+	altered, continued := frenchStandardSuffix(w)
+	if !altered || continued {
+		altered = frenchIVerbSuffix(w) || frenchVerbSuffix(w)
+	}
+
+	if altered {
+		switch w.at(1) {
+		case 'Y':
+			w.replace("Y", "i")
+		case 'ç':
+			w.replace("ç", "c")
+		}
+	} else {
+		frenchResidualSuffix(w)
+	}
+
+	for _, double := range []string{"enn", "onn", "ett", "ell", "eill"} {
+		if w.hasSuffix(double) {
+			w.runes = w.runes[:len(w.runes)-1]
+			break
+		}
+	}
+
+	for index := len(w.runes) - 1; index > 0 && !frenchVowel(w.runes[index]); index-- {
+		if r := w.runes[index-1]; r == 'é' || r == 'è' {
+			w.runes[index-1] = 'e'
+			break
+		}
+	}
+
+	replaceRunes(w.runes, map[rune]rune{'I': 'i', 'U': 'u', 'Y': 'y'})
+	return w.String()
+}
+
+// frenchMarkRV sets RV after the third letter if the word starts with two
+// vowels or with par, col or tap, and after the first vowel not at the start
+// of the word otherwise.
+func frenchMarkRV(w *word) {
+	w.rv = len(w.runes)
+	if len(w.runes) >= 3 {
+		prefix := string(w.runes[:3])
+		if frenchVowel(w.runes[0]) && frenchVowel(w.runes[1]) || prefix == "par" || prefix == "col" || prefix == "tap" {
+			w.rv = 3
+			return
+		}
+	}
+
+	for index := 1; index < len(w.runes); index++ {
+		if frenchVowel(w.runes[index]) {
+			w.rv = index + 1
+			return
+		}
+	}
+}
+
+// frenchStandardSuffix applies step 1 and reports whether it removed an
+// ending, and whether step 2 should be tried nonetheless.
+func frenchStandardSuffix(w *word) (removed, continued bool) {
+	suffix := w.findSuffix(frenchStep1, 0)
+	switch suffix {
+	case "":
+		return false, false
+	case "atrice", "ateur", "ation", "atrices", "ateurs", "ations":
+		if !w.in(suffix, w.p2) {
+			return false, false
+		}
+		w.trim(suffix)
+		frenchTrimInR2OrReplace(w, "ic", "iqU")
+	case "logie", "logies":
+		if !w.in(suffix, w.p2) {
+			return false, false
+		}
+		w.replace(suffix, "log")
+	case "usion", "ution", "usions", "utions":
+		if !w.in(suffix, w.p2) {
+			return false, false
+		}
+		w.replace(suffix, "u")
+	case "ence", "ences":
+		if !w.in(suffix, w.p2) {
+			return false, false
+		}
+		w.replace(suffix, "ent")
+	case "ement", "ements":
+		if !w.in(suffix, w.rv) {
+			return false, false
+		}
+		w.trim(suffix)
+		switch preceding := w.findSuffix(frenchEmentSuffixes, 0); preceding {
+		case "iv":
+			if w.trimInR2(preceding) != "" {
+				w.trimInR2("at")
+			}
+		case "eus":
+			if w.trimInR2(preceding) == "" && w.in(preceding, w.p1) {
+				w.replace(preceding, "eux")
+			}
+		case "abl", "iqU":
+			w.trimInR2(preceding)
+		case "ièr", "Ièr":
+			if w.in(preceding, w.rv) {
+				w.replace(preceding, "i")
+			}
+		}
+	case "ité", "ités":
+		if !w.in(suffix, w.p2) {
+			return false, false
+		}
+		w.trim(suffix)
+		switch preceding := w.findSuffix(frenchIteSuffixes, 0); preceding {
+		case "abil":
+			frenchTrimInR2OrReplace(w, preceding, "abl")
+		case "ic":
+			frenchTrimInR2OrReplace(w, preceding, "iqU")
+		case "iv":
+			w.trimInR2(preceding)
+		}
+	case "if", "ive", "ifs", "ives":
+		if !w.in(suffix, w.p2) {
+			return false, false
+		}
+		w.trim(suffix)
+		if w.trimInR2("at") != "" {
+			frenchTrimInR2OrReplace(w, "ic", "iqU")
+		}
+	case "eaux":
+		w.replace(suffix, "eau")
+	case "aux":
+		if !w.in(suffix, w.p1) {
+			return false, false
+		}
+		w.replace(suffix, "al")
+	case "euse", "euses":
+		switch {
+		case w.in(suffix, w.p2):
+			w.trim(suffix)
+		case w.in(suffix, w.p1):
+			w.replace(suffix, "eux")
+		default:
+			return false, false
+		}
+	case "issement", "issements":
+		before := w.at(len([]rune(suffix)) + 1)
+		if !w.in(suffix, w.p1) || before == 0 || frenchVowel(before) {
+			return false, false
+		}
+		w.trim(suffix)
+	case "amment":
+		if w.in(suffix, w.rv) {
+			w.replace(suffix, "ant")
+		}
+		return false, true
+	case "emment":
+		if w.in(suffix, w.rv) {
+			w.replace(suffix, "ent")
+		}
+		return false, true
+	case "ment", "ments":
+		start := w.suffixStart(suffix)
+		if start-1 >= w.rv && frenchVowel(w.runes[start-1]) {
+			w.trim(suffix)
+		}
+		return false, true
+	default:
+		if !w.in(suffix, w.p2) {
+			return false, false
+		}
+		w.trim(suffix)
+	}
+
+	return true, false
+}
+
+// frenchTrimInR2OrReplace trims suffix if it lies in R2 and replaces it with
+// replacement if it lies outside of R2.
+func frenchTrimInR2OrReplace(w *word, suffix, replacement string) {
+	switch {
+	case !w.hasSuffix(suffix):
+	case w.in(suffix, w.p2):
+		w.trim(suffix)
+	default:
+		w.replace(suffix, replacement)
+	}
+}
+
+func frenchIVerbSuffix(w *word) bool {
+	suffix := w.findSuffix(frenchStep2a, w.rv)
+	if suffix == "" {
+		return false
+	}
+
+	start := w.suffixStart(suffix)
+	if start-1 < w.rv || frenchVowel(w.runes[start-1]) || w.runes[start-1] == 'H' {
+		return false
+	}
+
+	w.trim(suffix)
+	return true
+}
+
+func frenchVerbSuffix(w *word) bool {
+	suffix := w.findSuffix(frenchStep2b, w.rv)
+	switch suffix {
+	case "":
+		return false
+	case "ions":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+	case "âmes", "ât", "âtes", "a", "ai", "aIent", "ais", "ait", "ant", "ante",
+		"antes", "ants", "as", "asse", "assent", "asses", "assiez", "assions":
+		w.trim(suffix)
+		if w.in("e", w.rv) {
+			w.trim("e")
+		}
+	default:
+		w.trim(suffix)
+	}
+
+	return true
+}
+
+func frenchResidualSuffix(w *word) {
+	if before := w.at(2); w.hasSuffix("s") && before != 0 && !strings.ContainsRune("aiosuè", before) {
+		w.trim("s")
+	}
+
+	suffix := w.findSuffix(frenchStep4, w.rv)
+	switch suffix {
+	case "ion":
+		before := w.at(4)
+		if w.in(suffix, w.p2) && (before == 's' || before == 't') && w.suffixStart(suffix)-1 >= w.rv {
+			w.trim(suffix)
+		}
+	case "ier", "ière", "Ier", "Ière":
+		w.replace(suffix, "i")
+	case "e":
+		w.trim(suffix)
+	case "ë":
+		if w.suffixStart("guë") >= w.rv {
+			w.trim(suffix)
+		}
+	}
+}
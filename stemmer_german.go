@@ -0,0 +1,103 @@
+package go_tf_idf
+
+import "strings"
+
+var (
+	germanVowel = vowels("aeiouyäöü")
+
+	germanStep1 = suffixes("em", "ern", "er", "e", "en", "es", "s")
+	germanStep2 = suffixes("en", "er", "est", "st")
+	germanStep3 = suffixes("end", "ung", "ig", "ik", "isch", "lich", "heit", "keit")
+)
+
+// GermanStemmer implements the Snowball German stemmer.
+func GermanStemmer(s string) string {
+	w := newWord(strings.ReplaceAll(s, "ß", "ss"))
+	for index := 1; index < len(w.runes)-1; index++ {
+		r := w.runes[index]
+		if (r == 'u' || r == 'y') && germanVowel(w.runes[index-1]) && germanVowel(w.runes[index+1]) {
+			w.runes[index] = r - 'a' + 'A'
+		}
+	}
+
+	w.markRegions(germanVowel)
+	if w.p1 < 3 {
+		w.p1 = 3
+	}
+
+	germanStep1Apply(w)
+	germanStep2Apply(w)
+	germanStep3Apply(w)
+
+	replaceRunes(w.runes, map[rune]rune{'U': 'u', 'Y': 'y', 'ä': 'a', 'ö': 'o', 'ü': 'u'})
+	return w.String()
+}
+
+func germanStep1Apply(w *word) {
+	suffix := w.findSuffix(germanStep1, 0)
+	if suffix == "" || !w.in(suffix, w.p1) {
+		return
+	}
+
+	switch suffix {
+	case "e", "en", "es":
+		w.trim(suffix)
+		if w.hasSuffix("niss") {
+			w.trim("s")
+		}
+	case "s":
+		if strings.ContainsRune("bdfghklmnrt", w.at(2)) {
+			w.trim(suffix)
+		}
+	default:
+		w.trim(suffix)
+	}
+}
+
+func germanStep2Apply(w *word) {
+	suffix := w.findSuffix(germanStep2, 0)
+	if suffix == "" || !w.in(suffix, w.p1) {
+		return
+	}
+
+	if suffix == "st" {
+		if !strings.ContainsRune("bdfghklmnt", w.at(3)) || len(w.runes) < 6 {
+			return
+		}
+	}
+
+	w.trim(suffix)
+}
+
+func germanStep3Apply(w *word) {
+	suffix := w.findSuffix(germanStep3, 0)
+	if suffix == "" || !w.in(suffix, w.p2) {
+		return
+	}
+
+	switch suffix {
+	case "end", "ung":
+		w.trim(suffix)
+		if w.in("ig", w.p2) && w.at(3) != 'e' {
+			w.trim("ig")
+		}
+	case "ig", "ik", "isch":
+		if w.at(len([]rune(suffix))+1) != 'e' {
+			w.trim(suffix)
+		}
+	case "lich", "heit":
+		w.trim(suffix)
+		if w.in("er", w.p1) {
+			w.trim("er")
+		} else if w.in("en", w.p1) {
+			w.trim("en")
+		}
+	case "keit":
+		w.trim(suffix)
+		if w.in("lich", w.p2) {
+			w.trim("lich")
+		} else if w.in("ig", w.p2) {
+			w.trim("ig")
+		}
+	}
+}
@@ -0,0 +1,161 @@
+package go_tf_idf
+
+import "strings"
+
+var (
+	italianVowel = vowels("aeiouàèìòù")
+
+	italianPronouns = suffixes(
+		"ci", "gli", "la", "le", "li", "lo", "mi", "ne", "si", "ti", "vi",
+		"sene", "gliela", "gliele", "glieli", "glielo", "gliene",
+		"mela", "mele", "meli", "melo", "mene", "tela", "tele", "teli", "telo", "tene",
+		"cela", "cele", "celi", "celo", "cene", "vela", "vele", "veli", "velo", "vene",
+	)
+	italianPronounVerbs = suffixes("ando", "endo", "ar", "er", "ir")
+
+	italianStep1 = suffixes(
+		"anza", "anze", "ico", "ici", "ica", "ice", "iche", "ichi", "ismo",
+		"ismi", "abile", "abili", "ibile", "ibili", "ista", "iste", "isti",
+		"istà", "istè", "istì", "oso", "osi", "osa", "ose", "mente", "atrice",
+		"atrici", "ante", "anti", "azione", "azioni", "atore", "atori",
+		"logia", "logie", "uzione", "uzioni", "usione", "usioni", "enza",
+		"enze", "amento", "amenti", "imento", "imenti", "amente", "ità",
+		"ivo", "ivi", "iva", "ive",
+	)
+	italianStep2 = suffixes(
+		"ammo", "ando", "ano", "are", "arono", "asse", "assero", "assi",
+		"assimo", "ata", "ate", "ati", "ato", "ava", "avamo", "avano", "avate",
+		"avi", "avo", "emmo", "enda", "ende", "endi", "endo", "erà", "erai",
+		"eranno", "ere", "erebbe", "erebbero", "erei", "eremmo", "eremo",
+		"ereste", "eresti", "erete", "erò", "erono", "essero", "ete", "eva",
+		"evamo", "evano", "evate", "evi", "evo", "iamo", "immo", "irà", "irai",
+		"iranno", "ire", "irebbe", "irebbero", "irei", "iremmo", "iremo",
+		"ireste", "iresti", "irete", "irò", "irono", "isca", "iscano", "isce",
+		"isci", "isco", "iscono", "issero", "ita", "ite", "iti", "ito", "iva",
+		"ivamo", "ivano", "ivate", "ivi", "ivo", "ar", "ir",
+	)
+)
+
+// ItalianStemmer implements the Snowball Italian stemmer.
+func ItalianStemmer(s string) string {
+	w := newWord(s)
+	replaceRunes(w.runes, map[rune]rune{'á': 'à', 'é': 'è', 'í': 'ì', 'ó': 'ò', 'ú': 'ù'})
+	for index, r := range w.runes {
+		switch {
+		case r == 'u' && index > 0 && w.runes[index-1] == 'q':
+			w.runes[index] = 'U'
+		case (r == 'u' || r == 'i') && index > 0 && index < len(w.runes)-1 &&
+			italianVowel(w.runes[index-1]) && italianVowel(w.runes[index+1]):
+			w.runes[index] = r - 'a' + 'A'
+		}
+	}
+
+	w.markRV(italianVowel)
+	w.markRegions(italianVowel)
+
+	italianAttachedPronoun(w)
+	if !italianStandardSuffix(w) {
+		if suffix := w.findSuffix(italianStep2, w.rv); suffix != "" {
+			w.trim(suffix)
+		}
+	}
+
+	if last := w.at(1); strings.ContainsRune("aeioàèìò", last) && w.in(string(last), w.rv) {
+		w.trim(string(last))
+		if w.in("i", w.rv) {
+			w.trim("i")
+		}
+	}
+
+	if w.in("ch", w.rv) || w.in("gh", w.rv) {
+		w.trim("h")
+	}
+
+	replaceRunes(w.runes, map[rune]rune{'I': 'i', 'U': 'u'})
+	return w.String()
+}
+
+func italianAttachedPronoun(w *word) {
+	pronoun := w.findSuffix(italianPronouns, 0)
+	if pronoun == "" {
+		return
+	}
+
+	before := &word{runes: w.runes[:w.suffixStart(pronoun)]}
+	verb := before.findSuffix(italianPronounVerbs, 0)
+	if verb == "" || before.suffixStart(verb) < w.rv {
+		return
+	}
+
+	switch verb {
+	case "ando", "endo":
+		w.trim(pronoun)
+	default:
+		w.replace(pronoun, "e")
+	}
+}
+
+func italianStandardSuffix(w *word) bool {
+	suffix := w.findSuffix(italianStep1, 0)
+	if suffix == "" {
+		return false
+	}
+
+	switch suffix {
+	case "azione", "azioni", "atore", "atori":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		w.trimInR2("ic")
+	case "logia", "logie":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "log")
+	case "uzione", "uzioni", "usione", "usioni":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "u")
+	case "enza", "enze":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "ente")
+	case "amento", "amenti", "imento", "imenti":
+		if !w.in(suffix, w.rv) {
+			return false
+		}
+		w.trim(suffix)
+	case "amente":
+		if !w.in(suffix, w.p1) {
+			return false
+		}
+		w.trim(suffix)
+		if w.trimInR2("iv", "os", "ic", "abil") == "iv" {
+			w.trimInR2("at")
+		}
+	case "ità":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		w.trimInR2("abil", "ic", "iv")
+	case "ivo", "ivi", "iva", "ive":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		if w.trimInR2("at") != "" {
+			w.trimInR2("ic")
+		}
+	default:
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+	}
+
+	return true
+}
@@ -0,0 +1,267 @@
+package go_tf_idf
+
+// PorterStemmer implements the original Porter stemming algorithm for English
+// as published by Martin Porter in 1980, including the bli and logi rules of
+// the reference implementation. Words containing anything but the letters a
+// to z are returned unchanged.
+func PorterStemmer(s string) string {
+	if len(s) <= 2 {
+		return s
+	}
+
+	for index := 0; index < len(s); index++ {
+		if s[index] < 'a' || s[index] > 'z' {
+			return s
+		}
+	}
+
+	p := &porter{b: []byte(s)}
+	p.step1ab()
+	p.step1c()
+	p.step2()
+	p.step3()
+	p.step4()
+	p.step5()
+	return string(p.b)
+}
+
+// porter holds the word being stemmed. j marks the end of the stem once ends
+// has matched a suffix.
+type porter struct {
+	b []byte
+	j int
+}
+
+func (p *porter) cons(i int) bool {
+	switch p.b[i] {
+	case 'a', 'e', 'i', 'o', 'u':
+		return false
+	case 'y':
+		return i == 0 || !p.cons(i-1)
+	}
+
+	return true
+}
+
+// m measures the number of consonant-vowel sequences in b[0:j].
+func (p *porter) m() int {
+	n := 0
+	i := 0
+	for {
+		if i >= p.j {
+			return n
+		}
+		if !p.cons(i) {
+			break
+		}
+		i++
+	}
+	i++
+
+	for {
+		for {
+			if i >= p.j {
+				return n
+			}
+			if p.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+		n++
+
+		for {
+			if i >= p.j {
+				return n
+			}
+			if !p.cons(i) {
+				break
+			}
+			i++
+		}
+		i++
+	}
+}
+
+func (p *porter) vowelInStem() bool {
+	for i := 0; i < p.j; i++ {
+		if !p.cons(i) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *porter) doubleConsonant(i int) bool {
+	if i < 1 || p.b[i] != p.b[i-1] {
+		return false
+	}
+
+	return p.cons(i)
+}
+
+// cvc reports whether b[i-2:i+1] is consonant-vowel-consonant with the last
+// consonant not being w, x or y.
+func (p *porter) cvc(i int) bool {
+	if i < 2 || !p.cons(i) || p.cons(i-1) || !p.cons(i-2) {
+		return false
+	}
+
+	switch p.b[i] {
+	case 'w', 'x', 'y':
+		return false
+	}
+
+	return true
+}
+
+func (p *porter) ends(suffix string) bool {
+	if len(suffix) > len(p.b) || string(p.b[len(p.b)-len(suffix):]) != suffix {
+		return false
+	}
+
+	p.j = len(p.b) - len(suffix)
+	return true
+}
+
+func (p *porter) setTo(s string) {
+	p.b = append(p.b[:p.j], s...)
+}
+
+func (p *porter) replaceIfMeasured(s string) {
+	if p.m() > 0 {
+		p.setTo(s)
+	}
+}
+
+func (p *porter) step1ab() {
+	if p.b[len(p.b)-1] == 's' {
+		switch {
+		case p.ends("sses"):
+			p.b = p.b[:len(p.b)-2]
+		case p.ends("ies"):
+			p.setTo("i")
+		case len(p.b) >= 2 && p.b[len(p.b)-2] != 's':
+			p.b = p.b[:len(p.b)-1]
+		}
+	}
+
+	if p.ends("eed") {
+		if p.m() > 0 {
+			p.b = p.b[:len(p.b)-1]
+		}
+		return
+	}
+
+	if !(p.ends("ed") || p.ends("ing")) || !p.vowelInStem() {
+		return
+	}
+
+	p.b = p.b[:p.j]
+	switch {
+	case p.ends("at"):
+		p.setTo("ate")
+	case p.ends("bl"):
+		p.setTo("ble")
+	case p.ends("iz"):
+		p.setTo("ize")
+	case p.doubleConsonant(len(p.b) - 1):
+		switch p.b[len(p.b)-1] {
+		case 'l', 's', 'z':
+		default:
+			p.b = p.b[:len(p.b)-1]
+		}
+	default:
+		p.j = len(p.b)
+		if p.m() == 1 && p.cvc(len(p.b)-1) {
+			p.b = append(p.b, 'e')
+		}
+	}
+}
+
+func (p *porter) step1c() {
+	if p.ends("y") && p.vowelInStem() {
+		p.b[len(p.b)-1] = 'i'
+	}
+}
+
+var porterStep2 = [][2]string{
+	{"ational", "ate"}, {"tional", "tion"}, {"enci", "ence"}, {"anci", "ance"},
+	{"izer", "ize"}, {"bli", "ble"}, {"alli", "al"}, {"entli", "ent"},
+	{"eli", "e"}, {"ousli", "ous"}, {"ization", "ize"}, {"ation", "ate"},
+	{"ator", "ate"}, {"alism", "al"}, {"iveness", "ive"}, {"fulness", "ful"},
+	{"ousness", "ous"}, {"aliti", "al"}, {"iviti", "ive"}, {"biliti", "ble"},
+	{"logi", "log"},
+}
+
+var porterStep3 = [][2]string{
+	{"icate", "ic"}, {"ative", ""}, {"alize", "al"}, {"iciti", "ic"},
+	{"ical", "ic"}, {"ful", ""}, {"ness", ""},
+}
+
+var porterStep4 = []string{
+	"al", "ance", "ence", "er", "ic", "able", "ible", "ant", "ement", "ment",
+	"ent", "ion", "ou", "ism", "ate", "iti", "ous", "ive", "ize",
+}
+
+// replaceLongest applies the rule with the longest suffix the word ends with.
+// Shorter rules are not tried if its condition fails.
+func (p *porter) replaceLongest(rules [][2]string) {
+	longest := -1
+	for index, rule := range rules {
+		if p.ends(rule[0]) && (longest < 0 || len(rule[0]) > len(rules[longest][0])) {
+			longest = index
+		}
+	}
+
+	if longest >= 0 {
+		p.ends(rules[longest][0])
+		p.replaceIfMeasured(rules[longest][1])
+	}
+}
+
+func (p *porter) step2() {
+	p.replaceLongest(porterStep2)
+}
+
+func (p *porter) step3() {
+	p.replaceLongest(porterStep3)
+}
+
+func (p *porter) step4() {
+	longest := ""
+	for _, suffix := range porterStep4 {
+		if len(suffix) > len(longest) && p.ends(suffix) {
+			longest = suffix
+		}
+	}
+
+	if longest == "" || !p.ends(longest) {
+		return
+	}
+
+	if longest == "ion" && (p.j == 0 || (p.b[p.j-1] != 's' && p.b[p.j-1] != 't')) {
+		return
+	}
+
+	if p.m() > 1 {
+		p.b = p.b[:p.j]
+	}
+}
+
+func (p *porter) step5() {
+	p.j = len(p.b)
+	if p.b[len(p.b)-1] == 'e' {
+		p.j = len(p.b) - 1
+		if a := p.m(); a > 1 || (a == 1 && !p.cvc(len(p.b)-2)) {
+			p.b = p.b[:len(p.b)-1]
+		}
+	}
+
+	p.j = len(p.b)
+	if p.b[len(p.b)-1] == 'l' && p.doubleConsonant(len(p.b)-1) && p.m() > 1 {
+		p.b = p.b[:len(p.b)-1]
+	}
+}
@@ -0,0 +1,135 @@
+package go_tf_idf
+
+import "strings"
+
+var (
+	portugueseVowel = vowels("aeiouáéíóúâêô")
+
+	portugueseStep1 = suffixes(
+		"eza", "ezas", "ico", "ica", "icos", "icas", "ismo", "ismos", "ável",
+		"ível", "ista", "istas", "oso", "osa", "osos", "osas", "amento",
+		"amentos", "imento", "imentos", "adora", "ador", "aça~o", "adoras",
+		"adores", "aço~es", "ante", "antes", "ância", "logia", "logias",
+		"uça~o", "uço~es", "ência", "ências", "amente", "mente", "idade",
+		"idades", "iva", "ivo", "ivas", "ivos", "ira", "iras",
+	)
+	portugueseStep2 = suffixes(
+		"ada", "ida", "ia", "aria", "eria", "iria", "ará", "ara", "erá", "era",
+		"irá", "ava", "asse", "esse", "isse", "aste", "este", "iste", "ei",
+		"arei", "erei", "irei", "am", "iam", "ariam", "eriam", "iriam", "aram",
+		"eram", "iram", "avam", "em", "arem", "erem", "irem", "assem", "essem",
+		"issem", "ado", "ido", "ando", "endo", "indo", "ara~o", "era~o",
+		"ira~o", "ar", "er", "ir", "as", "adas", "idas", "ias", "arias",
+		"erias", "irias", "arás", "aras", "erás", "eras", "irás", "avas", "es",
+		"ardes", "erdes", "irdes", "ares", "eres", "ires", "asses", "esses",
+		"isses", "astes", "estes", "istes", "is", "ais", "eis", "íeis",
+		"aríeis", "eríeis", "iríeis", "áreis", "areis", "éreis", "ereis",
+		"íreis", "ireis", "ásseis", "ésseis", "ísseis", "áveis", "ados",
+		"idos", "ámos", "amos", "íamos", "aríamos", "eríamos", "iríamos",
+		"áramos", "éramos", "íramos", "ávamos", "emos", "aremos", "eremos",
+		"iremos", "ássemos", "êssemos", "íssemos", "imos", "armos", "ermos",
+		"irmos", "eu", "iu", "ou", "ira", "iras",
+	)
+	portugueseStep4 = suffixes("os", "a", "i", "o", "á", "í", "ó")
+)
+
+// PortugueseStemmer implements the Snowball Portuguese stemmer.
+func PortugueseStemmer(s string) string {
+	s = strings.NewReplacer("ã", "a~", "õ", "o~").Replace(s)
+	w := newWord(s)
+	w.markRV(portugueseVowel)
+	w.markRegions(portugueseVowel)
+
+	if portugueseStandardSuffix(w) || portugueseVerbSuffix(w) {
+		if w.hasSuffix("ci") && w.in("i", w.rv) {
+			w.trim("i")
+		}
+	} else if suffix := w.findSuffix(portugueseStep4, w.rv); suffix != "" {
+		w.trim(suffix)
+	}
+
+	switch last := w.at(1); {
+	case (last == 'e' || last == 'é' || last == 'ê') && w.in(string(last), w.rv):
+		w.trim(string(last))
+		if w.hasSuffix("gu") && w.in("u", w.rv) || w.hasSuffix("ci") && w.in("i", w.rv) {
+			w.runes = w.runes[:len(w.runes)-1]
+		}
+	case last == 'ç':
+		w.replace("ç", "c")
+	}
+
+	return strings.NewReplacer("a~", "ã", "o~", "õ").Replace(w.String())
+}
+
+func portugueseStandardSuffix(w *word) bool {
+	suffix := w.findSuffix(portugueseStep1, 0)
+	if suffix == "" {
+		return false
+	}
+
+	switch suffix {
+	case "logia", "logias":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "log")
+	case "uça~o", "uço~es":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "u")
+	case "ência", "ências":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "ente")
+	case "amente":
+		if !w.in(suffix, w.p1) {
+			return false
+		}
+		w.trim(suffix)
+		if w.trimInR2("iv", "os", "ic", "ad") == "iv" {
+			w.trimInR2("at")
+		}
+	case "mente":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		w.trimInR2("ante", "avel", "ível")
+	case "idade", "idades":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		w.trimInR2("abil", "ic", "iv")
+	case "iva", "ivo", "ivas", "ivos":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		w.trimInR2("at")
+	case "ira", "iras":
+		if !w.in(suffix, w.rv) || w.at(len([]rune(suffix))+1) != 'e' {
+			return false
+		}
+		w.replace(suffix, "ir")
+	default:
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+	}
+
+	return true
+}
+
+func portugueseVerbSuffix(w *word) bool {
+	suffix := w.findSuffix(portugueseStep2, w.rv)
+	if suffix == "" {
+		return false
+	}
+
+	w.trim(suffix)
+	return true
+}
@@ -0,0 +1,117 @@
+package go_tf_idf
+
+import "strings"
+
+var (
+	russianVowel = vowels("аеиоуыэюя")
+
+	russianPerfectiveGerund = newRussianEndings(
+		[]string{"в", "вши", "вшись"},
+		"ив", "ивши", "ившись", "ыв", "ывши", "ывшись",
+	)
+	russianAdjective = newRussianEndings(nil,
+		"ее", "ие", "ые", "ое", "ими", "ыми", "ей", "ий", "ый", "ой", "ем", "им",
+		"ым", "ом", "его", "ого", "ему", "ому", "их", "ых", "ую", "юю", "ая",
+		"яя", "ою", "ею",
+	)
+	russianParticiple = newRussianEndings(
+		[]string{"ем", "нн", "вш", "ющ", "щ"},
+		"ивш", "ывш", "ующ",
+	)
+	russianReflexive = newRussianEndings(nil, "ся", "сь")
+	russianVerb      = newRussianEndings(
+		[]string{
+			"ла", "на", "ете", "йте", "ли", "й", "л", "ем", "н", "ло", "но", "ет",
+			"ют", "ны", "ть", "ешь", "нно",
+		},
+		"ила", "ыла", "ена", "ейте", "уйте", "ите", "или", "ыли", "ей", "уй",
+		"ил", "ыл", "им", "ым", "ен", "ило", "ыло", "ено", "ят", "ует", "уют",
+		"ит", "ыт", "ены", "ить", "ыть", "ишь", "ую", "ю",
+	)
+	russianNoun = newRussianEndings(nil,
+		"а", "ев", "ов", "ие", "ье", "е", "иями", "ями", "ами", "еи", "ии", "и",
+		"ией", "ей", "ой", "ий", "й", "иям", "ям", "ием", "ем", "ам", "ом", "о",
+		"у", "ах", "иях", "ях", "ы", "ь", "ию", "ью", "ю", "ия", "ья", "я",
+	)
+)
+
+// russianEndings is a class of Russian endings, some of which are only
+// removed when preceded by а or я.
+type russianEndings struct {
+	all    []string
+	afterA map[string]bool
+}
+
+func newRussianEndings(afterA []string, others ...string) russianEndings {
+	endings := russianEndings{afterA: make(map[string]bool, len(afterA))}
+	for _, ending := range afterA {
+		endings.afterA[ending] = true
+	}
+
+	endings.all = suffixes(append(others, afterA...)...)
+	return endings
+}
+
+// RussianStemmer implements the Snowball Russian stemmer.
+func RussianStemmer(s string) string {
+	w := newWord(strings.ReplaceAll(s, "ё", "е"))
+	for index, r := range w.runes {
+		if russianVowel(r) {
+			w.rv = index + 1
+			break
+		}
+	}
+	w.markRegions(russianVowel)
+
+	if !russianRemove(w, russianPerfectiveGerund) {
+		russianRemove(w, russianReflexive)
+		if russianRemove(w, russianAdjective) {
+			russianRemove(w, russianParticiple)
+		} else if !russianRemove(w, russianVerb) {
+			russianRemove(w, russianNoun)
+		}
+	}
+
+	if w.in("и", w.rv) {
+		w.trim("и")
+	}
+
+	if suffix := w.findSuffix([]string{"ость", "ост"}, w.rv); suffix != "" && w.in(suffix, w.p2) {
+		w.trim(suffix)
+	}
+
+	switch suffix := w.findSuffix([]string{"ейше", "ейш", "н", "ь"}, w.rv); suffix {
+	case "ейше", "ейш":
+		w.trim(suffix)
+		if w.in("нн", w.rv) {
+			w.trim("н")
+		}
+	case "н":
+		if w.in("нн", w.rv) {
+			w.trim("н")
+		}
+	case "ь":
+		w.trim(suffix)
+	}
+
+	return w.String()
+}
+
+// russianRemove removes the longest of endings found in RV and reports
+// whether it did.
+func russianRemove(w *word, endings russianEndings) bool {
+	suffix := w.findSuffix(endings.all, w.rv)
+	if suffix == "" {
+		return false
+	}
+
+	if endings.afterA[suffix] {
+		start := w.suffixStart(suffix)
+		if start-1 < w.rv || w.runes[start-1] != 'а' && w.runes[start-1] != 'я' {
+			return false
+		}
+	}
+
+	w.trim(suffix)
+	return true
+}
@@ -0,0 +1,175 @@
+package go_tf_idf
+
+var (
+	spanishVowel = vowels("aeiouáéíóúü")
+
+	spanishPronouns     = suffixes("me", "se", "sela", "selo", "selas", "selos", "la", "le", "lo", "las", "les", "los", "nos")
+	spanishPronounVerbs = suffixes("iéndo", "ándo", "ár", "ér", "ír", "ando", "iendo", "ar", "er", "ir", "yendo")
+
+	spanishStep1 = suffixes(
+		"anza", "anzas", "ico", "ica", "icos", "icas", "ismo", "ismos", "able",
+		"ables", "ible", "ibles", "ista", "istas", "oso", "osa", "osos", "osas",
+		"amiento", "amientos", "imiento", "imientos", "adora", "ador", "ación",
+		"adoras", "adores", "aciones", "ante", "antes", "ancia", "ancias",
+		"logía", "logías", "ución", "uciones", "encia", "encias", "amente",
+		"mente", "idad", "idades", "iva", "ivo", "ivas", "ivos",
+	)
+	spanishStep2a = suffixes("ya", "ye", "yan", "yen", "yeron", "yendo", "yo", "yó", "yas", "yes", "yais", "yamos")
+	spanishStep2b = suffixes(
+		"en", "es", "éis", "emos",
+		"arían", "arías", "arán", "arás", "aríais", "aría", "aréis", "aríamos", "aremos", "ará", "aré",
+		"erían", "erías", "erán", "erás", "eríais", "ería", "eréis", "eríamos", "eremos", "erá", "eré",
+		"irían", "irías", "irán", "irás", "iríais", "iría", "iréis", "iríamos", "iremos", "irá", "iré",
+		"aba", "ada", "ida", "ía", "ara", "iera", "ad", "ed", "id", "ase", "iese", "aste", "iste", "an",
+		"aban", "ían", "aran", "ieran", "asen", "iesen", "aron", "ieron", "ado", "ido", "ando", "iendo",
+		"ió", "ar", "er", "ir", "as", "abas", "adas", "idas", "ías", "aras", "ieras", "ases", "ieses",
+		"ís", "áis", "abais", "íais", "arais", "ierais", "aseis", "ieseis", "asteis", "isteis", "ados",
+		"idos", "amos", "ábamos", "íamos", "imos", "áramos", "iéramos", "iésemos", "ásemos",
+	)
+	spanishStep3 = suffixes("os", "a", "o", "á", "í", "ó", "e", "é")
+
+	spanishUnaccented = map[rune]rune{'á': 'a', 'é': 'e', 'í': 'i', 'ó': 'o', 'ú': 'u'}
+)
+
+// SpanishStemmer implements the Snowball Spanish stemmer.
+func SpanishStemmer(s string) string {
+	w := newWord(s)
+	w.markRV(spanishVowel)
+	w.markRegions(spanishVowel)
+
+	spanishAttachedPronoun(w)
+	if !spanishStandardSuffix(w) && !spanishYVerbSuffix(w) {
+		spanishVerbSuffix(w)
+	}
+	spanishResidualSuffix(w)
+
+	replaceRunes(w.runes, spanishUnaccented)
+	return w.String()
+}
+
+func spanishAttachedPronoun(w *word) {
+	pronoun := w.findSuffix(spanishPronouns, 0)
+	if pronoun == "" {
+		return
+	}
+
+	before := &word{runes: w.runes[:w.suffixStart(pronoun)]}
+	verb := before.findSuffix(spanishPronounVerbs, 0)
+	if verb == "" || before.suffixStart(verb) < w.rv {
+		return
+	}
+
+	switch verb {
+	case "iéndo", "ándo", "ár", "ér", "ír":
+		w.trim(pronoun)
+		replaceRunes(w.runes[len(w.runes)-len([]rune(verb)):], spanishUnaccented)
+	case "yendo":
+		if before.at(len(verb)+1) == 'u' {
+			w.trim(pronoun)
+		}
+	default:
+		w.trim(pronoun)
+	}
+}
+
+func spanishStandardSuffix(w *word) bool {
+	suffix := w.findSuffix(spanishStep1, 0)
+	if suffix == "" {
+		return false
+	}
+
+	switch suffix {
+	case "adora", "ador", "ación", "adoras", "adores", "aciones", "ante", "antes", "ancia", "ancias":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		if w.in("ic", w.p2) {
+			w.trim("ic")
+		}
+	case "logía", "logías":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "log")
+	case "ución", "uciones":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "u")
+	case "encia", "encias":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.replace(suffix, "ente")
+	case "amente":
+		if !w.in(suffix, w.p1) {
+			return false
+		}
+		w.trim(suffix)
+		if w.trimInR2("iv", "os", "ic", "ad") == "iv" {
+			w.trimInR2("at")
+		}
+	case "mente":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		w.trimInR2("ante", "able", "ible")
+	case "idad", "idades":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		w.trimInR2("abil", "ic", "iv")
+	case "iva", "ivo", "ivas", "ivos":
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+		w.trimInR2("at")
+	default:
+		if !w.in(suffix, w.p2) {
+			return false
+		}
+		w.trim(suffix)
+	}
+
+	return true
+}
+
+func spanishYVerbSuffix(w *word) bool {
+	suffix := w.findSuffix(spanishStep2a, w.rv)
+	if suffix == "" || w.at(len([]rune(suffix))+1) != 'u' {
+		return false
+	}
+
+	w.trim(suffix)
+	return true
+}
+
+func spanishVerbSuffix(w *word) {
+	suffix := w.findSuffix(spanishStep2b, w.rv)
+	switch suffix {
+	case "":
+	case "en", "es", "éis", "emos":
+		w.trim(suffix)
+		if w.hasSuffix("gu") {
+			w.trim("u")
+		}
+	default:
+		w.trim(suffix)
+	}
+}
+
+func spanishResidualSuffix(w *word) {
+	suffix := w.findSuffix(spanishStep3, 0)
+	if suffix == "" || !w.in(suffix, w.rv) {
+		return
+	}
+
+	w.trim(suffix)
+	if (suffix == "e" || suffix == "é") && w.hasSuffix("gu") && w.in("u", w.rv) {
+		w.trim("u")
+	}
+}
@@ -0,0 +1,51 @@
+package go_tf_idf
+
+import "strings"
+
+var (
+	swedishVowel = vowels("aeiouyäåö")
+
+	swedishStep1 = suffixes(
+		"a", "arna", "erna", "heterna", "orna", "ad", "e", "ade", "ande",
+		"arne", "are", "aste", "en", "anden", "aren", "heten", "ern", "ar",
+		"er", "heter", "or", "as", "arnas", "ernas", "ornas", "es", "ades",
+		"andes", "ens", "arens", "hetens", "erns", "at", "andet", "het",
+		"ast", "s",
+	)
+	swedishStep2 = suffixes("dd", "gd", "nn", "dt", "gt", "kt", "tt")
+	swedishStep3 = suffixes("lig", "ig", "els", "löst", "fullt")
+)
+
+// SwedishStemmer implements the Snowball Swedish stemmer.
+func SwedishStemmer(s string) string {
+	w := newWord(s)
+	w.markRegions(swedishVowel)
+	if w.p1 < 3 {
+		w.p1 = 3
+	}
+
+	switch suffix := w.findSuffix(swedishStep1, w.p1); suffix {
+	case "":
+	case "s":
+		if strings.ContainsRune("bcdfghjklmnoprtvy", w.at(2)) {
+			w.trim(suffix)
+		}
+	default:
+		w.trim(suffix)
+	}
+
+	if w.findSuffix(swedishStep2, w.p1) != "" {
+		w.runes = w.runes[:len(w.runes)-1]
+	}
+
+	switch suffix := w.findSuffix(swedishStep3, w.p1); suffix {
+	case "lig", "ig", "els":
+		w.trim(suffix)
+	case "löst":
+		w.replace(suffix, "lös")
+	case "fullt":
+		w.replace(suffix, "full")
+	}
+
+	return w.String()
+}
@@ -0,0 +1,174 @@
+package go_tf_idf
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestStemmers(t *testing.T) {
+	tests := []struct {
+		name    string
+		stemmer Stemmer
+		words   map[string]string
+	}{
+		{
+			name:    "porter",
+			stemmer: PorterStemmer,
+			words: map[string]string{
+				"caresses": "caress", "ponies": "poni", "agreed": "agre",
+				"hopping": "hop", "filing": "file", "happy": "happi",
+				"relational": "relat", "generalization": "gener",
+				"allowance": "allow", "oscillators": "oscil", "Hello": "Hello",
+			},
+		},
+		{
+			name:    "english",
+			stemmer: EnglishStemmer,
+			words: map[string]string{
+				"consignment": "consign", "knackered": "knacker",
+				"generously": "generous", "communication": "communic",
+				"arsenal": "arsenal", "running": "run", "dying": "die",
+				"skies": "sky", "succeeded": "succeed", "abilities": "abil",
+				"is": "is",
+			},
+		},
+		{
+			name:    "german",
+			stemmer: GermanStemmer,
+			words: map[string]string{
+				"häuser": "haus", "kategorischen": "kategor",
+				"aufeinanderfolgenden": "aufeinanderfolg",
+				"möglichkeiten":        "moglich", "kinder": "kind",
+			},
+		},
+		{
+			name:    "dutch",
+			stemmer: DutchStemmer,
+			words: map[string]string{
+				"lichamelijk": "licham", "opgaven": "opgav", "boeken": "boek",
+				"mogelijkheden": "mogelijk",
+			},
+		},
+		{
+			name:    "swedish",
+			stemmer: SwedishStemmer,
+			words: map[string]string{
+				"jaktkarlarne": "jaktkarl", "klokhet": "klok", "flickorna": "flick",
+				"husen": "hus",
+			},
+		},
+		{
+			name:    "danish",
+			stemmer: DanishStemmer,
+			words: map[string]string{
+				"indtagelse": "indtag", "indtages": "indtag", "hundene": "hund",
+			},
+		},
+		{
+			name:    "spanish",
+			stemmer: SpanishStemmer,
+			words: map[string]string{
+				"chicas": "chic", "cantaríamos": "cant", "comerlo": "com",
+				"nacionalidad": "nacional", "rápidamente": "rapid",
+			},
+		},
+		{
+			name:    "french",
+			stemmer: FrenchStemmer,
+			words: map[string]string{
+				"continuellement": "continuel", "abandonnée": "abandon",
+				"majestueusement": "majestu", "chevaux": "cheval",
+				"finissons": "fin", "chantaient": "chant",
+			},
+		},
+		{
+			name:    "italian",
+			stemmer: ItalianStemmer,
+			words: map[string]string{
+				"abbandonata": "abbandon", "abbandonò": "abbandon",
+				"nazionalità": "nazional", "parlandogli": "parl",
+			},
+		},
+		{
+			name:    "portuguese",
+			stemmer: PortugueseStemmer,
+			words: map[string]string{
+				"boataria": "boat", "bobalhões": "bobalhõ", "informação": "inform",
+				"rapidamente": "rapid",
+			},
+		},
+		{
+			name:    "russian",
+			stemmer: RussianStemmer,
+			words: map[string]string{
+				"вагонов": "вагон", "важнейшие": "важн", "бежавший": "бежа",
+				"сделавшись": "сдела", "объявление": "объявлен",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for word, want := range tt.words {
+				if got := tt.stemmer(word); got != want {
+					t.Errorf("stemmer(%q) = %v, want %v", word, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestStemFilter(t *testing.T) {
+	got := StemFilter(EnglishStemmer)([]string{"running", "cats"})
+	if want := []string{"run", "cat"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("StemFilter() = %v, want %v", got, want)
+	}
+}
+
+func TestTfIdf_WithStemmerForLanguage(t *testing.T) {
+	i := New(
+		WithStemmerForLanguage("en"),
+		WithDefaultStopWords(),
+		WithPositions(),
+		WithDocuments([]string{"The runner was running", "Dogs run fast"}),
+	)
+
+	if got, want := len(i.DocumentsWithTerm("run")), 2; got != want {
+		t.Errorf("len(DocumentsWithTerm()) = %v, want %v", got, want)
+	}
+
+	if got := i.Search("runs", 0); len(got) != 2 {
+		t.Errorf("len(Search()) = %v, want %v", len(got), 2)
+	}
+
+	for _, posting := range i.Postings("run") {
+		if posting.DocumentID != md5Hash("The runner was running") {
+			continue
+		}
+
+		if got, want := posting.Positions, []int{3}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Positions = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestWithStemmerForLanguage_Unknown(t *testing.T) {
+	i := New(WithStemmerForLanguage("xx"), WithDocuments([]string{"running"}))
+	if got := i.DocumentsWithTerm("running"); len(got) != 1 {
+		t.Errorf("DocumentsWithTerm() = %v, want the unstemmed term indexed", got)
+	}
+}
+
+func TestLookupStemmer(t *testing.T) {
+	stemmer, err := LookupStemmer("de")
+	if err != nil {
+		t.Fatalf("LookupStemmer() error = %v", err)
+	}
+	if got := stemmer("bestellungen"); got != "bestell" {
+		t.Errorf("LookupStemmer() stemmed %q, want %q", got, "bestell")
+	}
+
+	if _, err := LookupStemmer("de-AT"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("LookupStemmer() error = %v, want %v", err, ErrUnknownLanguage)
+	}
+}
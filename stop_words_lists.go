@@ -0,0 +1,180 @@
+package go_tf_idf
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StopWordLists maps ISO 639-1 language codes to the stop word list of the
+// language.
+var StopWordLists = map[string]map[string]bool{
+	"da": DanishList,
+	"de": GermanList,
+	"en": DefaultList,
+	"es": SpanishList,
+	"fr": FrenchList,
+	"it": ItalianList,
+	"nl": DutchList,
+	"pt": PortugueseList,
+	"ru": RussianList,
+	"sv": SwedishList,
+}
+
+// WithStopWordsForLanguage adds the stop word list registered for lang in
+// StopWordLists. The option is ignored if there is none; languages from
+// configuration or user input should be checked with LookupStopWords and
+// passed to WithStopWords.
+func WithStopWordsForLanguage(lang string) Option {
+	return func(tfIdf *TfIdf) {
+		for word := range StopWordLists[lang] {
+			tfIdf.StopWords.AddWord(word)
+		}
+	}
+}
+
+// LookupStopWords returns the sorted stop word list registered for lang in
+// StopWordLists, or ErrUnknownLanguage if there is none.
+func LookupStopWords(lang string) ([]string, error) {
+	list, ok := StopWordLists[lang]
+	if !ok {
+		return nil, fmt.Errorf("%w %q: no stop words", ErrUnknownLanguage, lang)
+	}
+
+	words := make([]string, 0, len(list))
+	for word := range list {
+		words = append(words, word)
+	}
+	sort.Strings(words)
+
+	return words, nil
+}
+
+var DanishList = stopWordList(`
+	ad af alle alt anden at blev blive bliver da de dem den denne der deres det
+	dette dig din disse dog du efter eller en end er et for fra ham han hans har
+	havde have hende hendes her hos hun hvad hvis hvor i ikke ind jeg jer jo
+	kunne man mange med meget men mig min mine mit mod ned noget nogle nu når og
+	også om op os over på selv sig sin sine sit skal skulle som sådan thi til ud
+	under var vi vil ville vor være været
+`)
+
+var DutchList = stopWordList(`
+	aan al alles als altijd andere ben bij daar dan dat de der deze die dit doch
+	doen door dus een eens en er ge geen geweest haar had heb hebben heeft hem
+	het hier hij hoe hun iemand iets ik in is ja je kan kon kunnen maar me meer
+	men met mij mijn moet na naar niet niets nog nu of om omdat onder ons ook op
+	over reeds te tegen toch toen tot u uit uw van veel voor want waren was wat
+	werd wezen wie wil worden wordt zal ze zelf zich zij zijn zo zonder zou
+`)
+
+var FrenchList = stopWordList(`
+	ai aie aient aies ait as au aura aurai auraient aurais aurait auras aurez
+	auriez aurions aurons auront aux avaient avais avait avec avez aviez avions
+	avons ayant ayante ayantes ayants ayez ayons c ce ces d dans de des du elle
+	en es est et eu eue eues eurent eus eusse eussent eusses eussiez eussions
+	eut eux eûmes eût eûtes furent fus fusse fussent fusses fussiez fussions fut
+	fûmes fût fûtes il ils j je l la le les leur lui m ma mais me mes moi mon
+	même n ne nos notre nous on ont ou par pas pour qu que qui s sa se sera
+	serai seraient serais serait seras serez seriez serions serons seront ses
+	soient sois soit sommes son sont soyez soyons suis sur t ta te tes toi ton
+	tu un une vos votre vous y à étaient étais était étant étante étantes
+	étants étiez étions été étée étées étés êtes
+`)
+
+var GermanList = stopWordList(`
+	aber alle allem allen aller alles als also am an ander andere anderem
+	anderen anderer anderes anderm andern anders auch auf aus bei bin bis bist
+	da damit dann das dass daß dasselbe dazu dein deine deinem deinen deiner
+	deines dem demselben den denn denselben der derer derselbe derselben des
+	desselben dessen dich die dies diese dieselbe dieselben diesem diesen dieser
+	dieses dir doch dort du durch ein eine einem einen einer eines einig einige
+	einigem einigen einiger einiges einmal er es etwas euch euer eure eurem euren
+	eurer eures für gegen gewesen hab habe haben hat hatte hatten hier hin
+	hinter ich ihm ihn ihnen ihr ihre ihrem ihren ihrer ihres im in indem ins
+	ist jede jedem jeden jeder jedes jene jenem jenen jener jenes jetzt kann
+	kein keine keinem keinen keiner keines können könnte machen man manche
+	manchem manchen mancher manches mein meine meinem meinen meiner meines mich
+	mir mit muss musste nach nicht nichts noch nun nur ob oder ohne sehr sein
+	seine seinem seinen seiner seines selbst sich sie sind so solche solchem
+	solchen solcher solches soll sollte sondern sonst um und uns unser unsere
+	unserem unseren unseres unter viel vom von vor war waren warst was weg weil
+	weiter welche welchem welchen welcher welches wenn werde werden wie wieder
+	will wir wird wirst wo wollen wollte während würde würden zu zum zur zwar
+	zwischen über
+`)
+
+var ItalianList = stopWordList(`
+	a abbia abbiamo ad agl agli ai al all alla alle allo anche avete aveva
+	avevano avevo c che chi ci coi col come con contro cui da dagl dagli dai dal
+	dall dalla dalle dallo degl degli dei del dell della delle dello di dov dove
+	e ed era erano ero essere fu fui furono gli ha hai hanno ho i il in io l la
+	le lei li lo loro lui ma mi mia mie miei mio ne negl negli nei nel nell
+	nella nelle nello noi non nostra nostre nostri nostro o per perché più
+	quale quanta quante quanti quanto quella quelle quelli quello questa queste
+	questi questo sei si sia siamo siete sono sta stai stanno stata state stati
+	stato stiamo sto su sua sue sugl sugli sui sul sull sulla sulle sullo suo
+	suoi ti tra tu tua tue tuo tuoi tutti tutto un una uno vi voi vostra vostre
+	vostri vostro è
+`)
+
+var PortugueseList = stopWordList(`
+	a ao aos aquela aquelas aquele aqueles aquilo as até com como da das de dela
+	delas dele deles depois do dos e ela elas ele eles em entre era eram esse
+	essa essas esses esta estamos estas estava estavam este esteve estes
+	estive estivemos estiveram estou está estão eu foi fomos foram fui havemos
+	hei houve houvemos houveram há hão isso isto já lhe lhes mais mas me mesmo
+	meu meus minha minhas muito na nas nem no nos nossa nossas nosso nossos num
+	numa não nós o os ou para pela pelas pelo pelos por qual quando que quem se
+	seja sejam sem seu seus somos sou sua suas são só também te tem temos tenho
+	teu teus teve tinha tinham tive tivemos tiveram tu tua tuas têm um uma você
+	vocês vos à às éramos
+`)
+
+var RussianList = stopWordList(`
+	а без более больше будет будто бы был была были было быть в вам вас ведь
+	во вот впрочем все всегда всего всех всю вы где да даже два для до другой
+	его ее ей ему если есть еще ж же за зачем здесь и из или им иногда их к как
+	какая какой когда конечно кто куда ли лучше между меня мне много может можно
+	мой моя мы на над надо наконец нас не него нее ней нельзя нет ни нибудь
+	никогда ним них ничего но ну о об один он она они опять от перед по под
+	после потом потому почти при про раз разве с сам свою себе себя сейчас со
+	совсем так такой там тебя тем теперь то тогда того тоже только том тот три
+	тут ты у уж уже хорошо хоть чего чем через что чтоб чтобы чуть эти этого
+	этой этом этот эту я
+`)
+
+var SpanishList = stopWordList(`
+	a al algo algunas algunos ante antes como con contra cual cuando de del
+	desde donde durante e el ella ellas ellos en entre era erais eran eras eres
+	es esa esas ese eso esos esta estaba estabas estaban estamos estar estas
+	este esto estos estoy estuve estuvo está están estás fue fueron fui fuimos
+	ha habéis había habían han has hasta hay haya he hemos hube hubo la las le
+	les lo los me mi mis mucho muchos muy más mí mía mías mío míos nada ni no
+	nos nosotras nosotros nuestra nuestras nuestro nuestros o os otra otras otro
+	otros para pero poco por porque que quien quienes qué se sea sean ser sin
+	sobre sois somos son soy su sus suya suyas suyo suyos sí también tanto te
+	tenemos tener tengo ti tiene tienen todo todos tu tus tuya tuyas tuyo tuyos
+	tú un una uno unos vosotras vosotros vuestra vuestras vuestro vuestros y ya
+	yo él éramos
+`)
+
+var SwedishList = stopWordList(`
+	alla allt att av blev bli blir blivit de dem den denna deras dess dessa det
+	detta dig din dina ditt du där då efter ej eller en er era ert ett från för
+	ha hade han hans har henne hennes hon honom hur här i icke ingen inom inte
+	jag ju kan kunde man med mellan men mig min mina mitt mot mycket ni nu när
+	någon något några och om oss på samma sedan sig sin sina sitta själv skulle
+	som så sådan sådana sånt till under upp ut utan vad var vara varför varit
+	varje vars vart vem vi vid vilka vilkas vilken vilket vår våra vårt än är
+	åt över
+`)
+
+func stopWordList(words string) map[string]bool {
+	list := make(map[string]bool)
+	for _, word := range strings.Fields(words) {
+		list[word] = true
+	}
+
+	return list
+}
@@ -1,6 +1,10 @@
 package go_tf_idf
 
-import "testing"
+import (
+	"errors"
+	"sort"
+	"testing"
+)
 
 func TestStopWords_Matches(t *testing.T) {
 	type fields struct {
@@ -86,3 +90,76 @@ func TestStopWords_AddFilter(t *testing.T) {
 		t.Errorf("AddIgnoreFilter() = %v, want %v", len(w.Filters), 1)
 	}
 }
+
+func TestWithStopWordsForLanguage(t *testing.T) {
+	tests := []struct {
+		name            string
+		lang            string
+		wordAndExpected map[string]bool
+	}{
+		{
+			name: "german",
+			lang: "de",
+			wordAndExpected: map[string]bool{
+				"und":  true,
+				"über": true,
+				"haus": false,
+			},
+		},
+		{
+			name: "russian",
+			lang: "ru",
+			wordAndExpected: map[string]bool{
+				"и":   true,
+				"дом": false,
+			},
+		},
+		{
+			name: "english",
+			lang: "en",
+			wordAndExpected: map[string]bool{
+				"the":   true,
+				"house": false,
+			},
+		},
+		{
+			name: "unknown",
+			lang: "xx",
+			wordAndExpected: map[string]bool{
+				"the": false,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(WithStopWordsForLanguage(tt.lang))
+			for word, expected := range tt.wordAndExpected {
+				if got := i.StopWords.Matches(word); got != expected {
+					t.Errorf("Matches(%q) = %v, want %v", word, got, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestStopWordLists(t *testing.T) {
+	for _, lang := range []string{"da", "de", "en", "es", "fr", "it", "nl", "pt", "ru", "sv"} {
+		if len(StopWordLists[lang]) == 0 {
+			t.Errorf("StopWordLists[%q] is empty", lang)
+		}
+	}
+}
+
+func TestLookupStopWords(t *testing.T) {
+	words, err := LookupStopWords("de")
+	if err != nil {
+		t.Fatalf("LookupStopWords() error = %v", err)
+	}
+	if len(words) != len(GermanList) || !sort.StringsAreSorted(words) {
+		t.Errorf("LookupStopWords() = %d words, want the %d sorted words of GermanList", len(words), len(GermanList))
+	}
+
+	if _, err := LookupStopWords("de-AT"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("LookupStopWords() error = %v, want %v", err, ErrUnknownLanguage)
+	}
+}
@@ -5,6 +5,16 @@ import (
 	"encoding/hex"
 	"errors"
 	"math"
+	"sync"
+)
+
+var (
+	ErrDocumentExists   = errors.New("document with id already exists")
+	ErrEmptyDocument    = errors.New("document contains no tokens")
+	ErrDocumentNotFound = errors.New("document not found")
+	ErrEmptyCorpus      = errors.New("corpus contains no documents")
+	ErrUnknownTerm      = errors.New("term not contained in any document")
+	ErrFrozen           = errors.New("corpus statistics are frozen")
 )
 
 type Option func(idf *TfIdf)
@@ -31,27 +41,73 @@ func WithDocuments(documents []string) Option {
 	}
 }
 
+// WithSmoothInverseDocumentFrequency adds one to the document count and the
+// document frequency of every term, as if an extra document contained every
+// term once, and adds one to the result.
+func WithSmoothInverseDocumentFrequency() Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.smoothIdf = true
+	}
+}
+
+func WithCompareMode(mode CompareMode) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.compareMode = mode
+	}
+}
+
 func WithComparator(comparator Comparator) Option {
 	return func(tfIdf *TfIdf) {
 		tfIdf.comparator = comparator
 	}
 }
 
+// TfIdf is safe for concurrent use by multiple goroutines. Documents and
+// StopWords must not be modified directly while other goroutines use the
+// TfIdf.
 type TfIdf struct {
-	Documents              map[string]Document
-	StopWords              *StopWords
-	comparator             Comparator
-	termToIndex            map[string]int
-	documentsWithTermCount map[string]int
+	mu sync.RWMutex
+
+	Documents      map[string]Document
+	StopWords      *StopWords
+	analyzer       *Analyzer
+	stemmer        Stemmer
+	detector       LanguageDetector
+	nGramMin       int
+	nGramMax       int
+	nGramStopWords NGramStopWords
+
+	minDocumentFrequency int
+	maxDocumentFrequency float64
+	maxFeatures          int
+
+	comparator       Comparator
+	sparseComparator SparseComparator
+	mostSimilarTerms int
+	compareMode      CompareMode
+	scorer           Scorer
+	weighting        *Weighting
+	smoothIdf        bool
+	index            *invertedIndex
+	recordPositions  bool
+	frozen           *corpusStats
+	termToIndex      map[string]int
+	vocabulary       []string
 }
 
 func DefaultOptions() *TfIdf {
 	return &TfIdf{
-		Documents:              make(map[string]Document, 0),
-		StopWords:              NewEmptyStopWords(),
-		comparator:             CosineComparator,
-		termToIndex:            make(map[string]int, 0),
-		documentsWithTermCount: make(map[string]int, 0),
+		Documents:        make(map[string]Document, 0),
+		StopWords:        NewEmptyStopWords(),
+		analyzer:         NewAnalyzer(DefaultTokenizer),
+		comparator:       CosineComparator,
+		mostSimilarTerms: DefaultMostSimilarTerms,
+		scorer:           TfIdfScorer,
+		index:            newInvertedIndex(),
+		nGramMin:         1,
+		nGramMax:         1,
+		termToIndex:      make(map[string]int, 0),
+		vocabulary:       make([]string, 0),
 	}
 }
 
@@ -65,9 +121,18 @@ func New(opts ...Option) *TfIdf {
 }
 
 type Document struct {
+	ID           string
 	AllTokens    []string
 	TermCount    map[string]int
 	UniqueTokens []string
+
+	// Language is the detected ISO 639-1 language code of the document, or ""
+	// if language detection is disabled.
+	Language string
+
+	// positions maps every term to the token positions it occurs at. It is
+	// only recorded when the index records positions.
+	positions map[string][]int
 }
 
 func (d Document) TermFrequency(term string) float64 {
@@ -107,93 +172,490 @@ func (d Document) GetVectors(other Document) ([]float64, []float64) {
 
 type Comparator func(vector1, vector2 []float64) float64
 
-func (i TfIdf) Compare(document1, document2 string) (float64, error) {
-	doc1 := i.GetDocument(document1)
-	doc2 := i.GetDocument(document2)
-	if doc1 == nil || doc2 == nil {
-		return 0, errors.New("cannot compare with nil document")
+// CompareMode selects the vectors built by Compare. CompareTermFrequency uses
+// raw term frequencies, CompareTermFrequencyInverseDocumentFrequency weighs
+// them by inverse document frequency so that terms common to the corpus
+// contribute less.
+type CompareMode int
+
+const (
+	CompareTermFrequency CompareMode = iota
+	CompareTermFrequencyInverseDocumentFrequency
+)
+
+func (i *TfIdf) Compare(document1, document2 string) (float64, error) {
+	return i.CompareByID(md5Hash(document1), md5Hash(document2))
+}
+
+func (i *TfIdf) CompareByID(id1, id2 string) (float64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	doc1, ok1 := i.Documents[id1]
+	doc2, ok2 := i.Documents[id2]
+	if !ok1 || !ok2 {
+		return 0, ErrDocumentNotFound
+	}
+
+	return i.compare(doc1, doc2), nil
+}
+
+func (i *TfIdf) compare(doc1, doc2 Document) float64 {
+	return i.comparison(doc1)(doc2)
+}
+
+// comparison returns a function comparing doc with other documents like
+// compare. The weights of doc are computed once, so that comparing it with
+// many documents only weighs the other side.
+func (i *TfIdf) comparison(doc Document) func(other Document) float64 {
+	weights := i.compareWeights(doc)
+	if i.sparseComparator != nil {
+		vector := i.sparseVector(weights)
+		return func(other Document) float64 {
+			return i.sparseComparator(vector, i.sparseVector(i.compareWeights(other)))
+		}
+	}
+
+	return func(other Document) float64 {
+		vector1, vector2 := alignWeights(doc.UniqueTokens, weights, other.UniqueTokens, i.compareWeights(other))
+		return i.comparator(vector1, vector2)
+	}
+}
+
+// compareWeights returns the weight of every term of doc for the configured
+// CompareMode.
+func (i *TfIdf) compareWeights(doc Document) map[string]float64 {
+	if i.compareMode == CompareTermFrequency {
+		return termFrequencies(doc)
+	}
+
+	return i.termWeights(doc)
+}
+
+// alignWeights returns vectors holding the weights of the terms of both
+// documents, in the order of terms1 followed by the terms only in terms2.
+func alignWeights(terms1 []string, weights1 map[string]float64, terms2 []string, weights2 map[string]float64) ([]float64, []float64) {
+	vector1 := make([]float64, 0, len(terms1)+len(terms2))
+	vector2 := make([]float64, 0, len(terms1)+len(terms2))
+	for _, term := range terms1 {
+		vector1 = append(vector1, weights1[term])
+		vector2 = append(vector2, weights2[term])
+	}
+	for _, term := range terms2 {
+		if _, ok := weights1[term]; !ok {
+			vector1 = append(vector1, 0)
+			vector2 = append(vector2, weights2[term])
+		}
 	}
 
-	vector1, vector2 := doc1.GetVectors(*doc2)
-	return i.comparator(vector1, vector2), nil
+	return vector1, vector2
+}
+
+// termWeights returns the term frequency inverse document frequency of every
+// term of doc, using the configured Weighting if any.
+func (i *TfIdf) termWeights(doc Document) map[string]float64 {
+	if i.weighting != nil {
+		return i.weights(doc)
+	}
+
+	weights := make(map[string]float64, len(doc.UniqueTokens))
+	for _, term := range doc.UniqueTokens {
+		weights[term] = doc.TermFrequency(term) * i.inverseDocumentFrequency(term)
+	}
+
+	return weights
+}
+
+func (i *TfIdf) GetDocument(document string) *Document {
+	return i.GetDocumentByID(md5Hash(document))
 }
 
-func (i TfIdf) GetDocument(document string) *Document {
-	hash := md5Hash(document)
-	if doc, ok := i.Documents[hash]; ok {
+func (i *TfIdf) GetDocumentByID(id string) *Document {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if doc, ok := i.Documents[id]; ok {
 		return &doc
 	}
 
 	return nil
 }
 
-func (i TfIdf) InverseDocumentFrequency(term string) float64 {
-	termCount := i.documentsWithTermCount[term]
-	documentCount := len(i.Documents)
+// InverseDocumentFrequency returns log10(N/df) for term, or
+// log10((1+N)/(1+df))+1 if smoothing is enabled. Without smoothing, terms not
+// contained in any document and terms of an empty corpus have an inverse
+// document frequency of 0.
+func (i *TfIdf) InverseDocumentFrequency(term string) float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return i.inverseDocumentFrequency(term)
+}
+
+func (i *TfIdf) inverseDocumentFrequency(term string) float64 {
+	if i.frozen != nil && i.weighting == nil {
+		if idf, ok := i.frozen.inverseDocumentFrequencies[term]; ok {
+			return idf
+		}
+	}
+
+	termCount := i.documentFrequency(term)
+	documentCount := i.documentCount()
+	if i.smoothIdf {
+		return math.Log10(float64(1+documentCount)/float64(1+termCount)) + 1
+	}
+
+	if termCount == 0 || documentCount == 0 {
+		return 0
+	}
 	return math.Log10(float64(documentCount) / float64(termCount))
 }
 
-func (i TfIdf) TermFrequencyInverseDocumentFrequencyForTerm(term string, document string) float64 {
-	doc := i.GetDocument(document)
-	if doc == nil {
+// appliedInverseDocumentFrequency returns the inverse document frequency that
+// weighs term in vectors, which is that of the Weighting if one is configured.
+// Frozen statistics hold it for every term of the vocabulary.
+func (i *TfIdf) appliedInverseDocumentFrequency(term string) float64 {
+	if i.frozen != nil {
+		if idf, ok := i.frozen.inverseDocumentFrequencies[term]; ok {
+			return idf
+		}
+	}
+
+	if i.weighting != nil {
+		return i.weighting.inverseDocumentFrequency(i.documentFrequency(term), i.documentCount())
+	}
+
+	return i.inverseDocumentFrequency(term)
+}
+
+// LookupInverseDocumentFrequency is like InverseDocumentFrequency but returns
+// ErrEmptyCorpus or ErrUnknownTerm instead of a default value.
+func (i *TfIdf) LookupInverseDocumentFrequency(term string) (float64, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if i.documentCount() == 0 {
+		return 0, ErrEmptyCorpus
+	}
+
+	if i.documentFrequency(term) == 0 {
+		return 0, ErrUnknownTerm
+	}
+
+	return i.inverseDocumentFrequency(term), nil
+}
+
+func (i *TfIdf) TermFrequencyInverseDocumentFrequencyForTerm(term string, document string) float64 {
+	return i.TermFrequencyInverseDocumentFrequencyForTermByID(term, md5Hash(document))
+}
+
+func (i *TfIdf) TermFrequencyInverseDocumentFrequencyForTermByID(term string, id string) float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	doc, ok := i.Documents[id]
+	if !ok {
 		return 0
 	}
-	return doc.TermFrequency(term) * i.InverseDocumentFrequency(term)
+	return i.termWeights(doc)[term]
+}
+
+func (i *TfIdf) TermFrequencyInverseDocumentFrequencyForDocument(document string) []float64 {
+	return i.TermFrequencyInverseDocumentFrequencyForDocumentByID(md5Hash(document))
 }
 
-func (i TfIdf) TermFrequencyInverseDocumentFrequencyForDocument(document string) []float64 {
+func (i *TfIdf) TermFrequencyInverseDocumentFrequencyForDocumentByID(id string) []float64 {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
 	vec := make([]float64, len(i.termToIndex))
-	doc := i.GetDocument(document)
-	if doc == nil {
+	doc, ok := i.Documents[id]
+	if !ok {
 		return vec
 	}
 
-	for _, term := range doc.AllTokens {
-		idf := i.InverseDocumentFrequency(term)
-		tfidf := doc.TermFrequency(term) * idf
-		vec[i.termToIndex[term]] = tfidf
+	for term, weight := range i.termWeights(doc) {
+		vec[i.termToIndex[term]] = weight
 	}
 
 	return vec
 }
 
-func (i TfIdf) AddDocument(document string) {
-	hash := md5Hash(document)
-	if _, ok := i.Documents[hash]; ok {
-		return
+func (i *TfIdf) AddDocument(document string) {
+	_ = i.AddDocumentWithID(md5Hash(document), document)
+}
+
+func (i *TfIdf) AddDocumentWithID(id string, document string) error {
+	doc, err := i.newDocument(id, document)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.frozen != nil {
+		return ErrFrozen
+	}
+
+	if _, ok := i.Documents[id]; ok {
+		return ErrDocumentExists
+	}
+
+	if err != nil {
+		return err
+	}
+
+	i.addDocument(doc)
+	return nil
+}
+
+func (i *TfIdf) RemoveDocument(document string) {
+	_ = i.RemoveDocumentByID(md5Hash(document))
+}
+
+// RemoveDocumentByID removes the document stored under id. Terms no longer
+// contained in any document leave the vocabulary, and the last term of the
+// vocabulary takes the vector index of each.
+func (i *TfIdf) RemoveDocumentByID(id string) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.frozen != nil {
+		return ErrFrozen
+	}
+
+	doc, ok := i.Documents[id]
+	if !ok {
+		return ErrDocumentNotFound
+	}
+
+	i.removeDocument(doc)
+	return nil
+}
+
+// UpdateDocument replaces the content of the document stored under id. The
+// existing document is left untouched if the new content has no tokens.
+func (i *TfIdf) UpdateDocument(id string, document string) error {
+	doc, err := i.newDocument(id, document)
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	if i.frozen != nil {
+		return ErrFrozen
+	}
+
+	old, ok := i.Documents[id]
+	if !ok {
+		return ErrDocumentNotFound
+	}
+
+	if err != nil {
+		return err
 	}
 
-	allTokens := Tokenize(document)
+	// Adding before releasing keeps terms shared by both versions at their
+	// current index.
+	i.addDocument(doc)
+
+	stale := make([]string, 0)
+	for _, token := range old.UniqueTokens {
+		if _, ok := doc.TermCount[token]; !ok {
+			stale = append(stale, token)
+		}
+	}
+	i.dropTerms(i.index.remove(id, stale))
+	return nil
+}
+
+// newDocument analyzes document in its detected language. It must be called
+// without holding the lock, which it takes to read the stop words Load may
+// replace.
+func (i *TfIdf) newDocument(id string, document string) (Document, error) {
+	return i.analyze(id, document, false)
+}
+
+// newQuery analyzes query like newDocument, but in the language chosen by
+// queryLanguage since queries are often too short for reliable detection.
+func (i *TfIdf) newQuery(query string) (Document, error) {
+	return i.analyze("", query, true)
+}
+
+func (i *TfIdf) analyze(id string, document string, query bool) (Document, error) {
+	allTokens := i.analyzer.Tokenize(document)
 	if len(allTokens) == 0 {
-		return
+		return Document{}, ErrEmptyDocument
+	}
+
+	lang := i.language(document)
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	if query {
+		lang = i.queryLanguage(lang)
+	}
+
+	var positions map[string][]int
+	if i.recordPositions {
+		positions = make(map[string][]int, 0)
 	}
 
 	termCount := make(map[string]int, 0)
 	uniqueTokens := make([]string, 0)
-	for _, token := range allTokens {
-		if i.StopWords.Matches(token) {
-			continue
+	for _, occurrence := range i.terms(allTokens, lang) {
+		term := occurrence.term
+		termCount[term]++
+
+		if termCount[term] == 1 {
+			uniqueTokens = append(uniqueTokens, term)
+		}
+
+		if positions != nil {
+			positions[term] = append(positions[term], occurrence.position)
 		}
+	}
+
+	return Document{
+		ID:           id,
+		Language:     lang,
+		AllTokens:    allTokens,
+		UniqueTokens: uniqueTokens,
+		TermCount:    termCount,
+		positions:    positions,
+	}, nil
+}
+
+// language returns the detected language of text, or "" if language
+// detection is disabled.
+func (i *TfIdf) language(text string) string {
+	if i.detector == nil {
+		return ""
+	}
+
+	return i.detector(text)
+}
 
-		termCount[token]++
+// queryLanguage returns the language a query detected to be in lang is
+// analyzed in. Detection often errs on short queries, so lang is kept only if
+// some document is in it. Otherwise the query is analyzed in the language
+// most documents are in, ties going to the first in alphabetical order, or in
+// none if no document has a detected language.
+func (i *TfIdf) queryLanguage(lang string) string {
+	if i.detector == nil {
+		return ""
+	}
+
+	languages := i.languageCounts()
+	if lang != "" && languages[lang] > 0 {
+		return lang
+	}
 
-		if termCount[token] == 1 {
-			uniqueTokens = append(uniqueTokens, token)
-			i.documentsWithTermCount[token]++
+	best := ""
+	for lang, count := range languages {
+		if lang == "" {
+			continue
+		}
+
+		if best == "" || count > languages[best] || count == languages[best] && lang < best {
+			best = lang
 		}
+	}
+
+	return best
+}
+
+// term returns the term counted for token in a text of language lang, or
+// false if token is a stop word.
+func (i *TfIdf) term(token string, lang string) (string, bool) {
+	if i.StopWords.Matches(token) || StopWordLists[lang][token] {
+		return "", false
+	}
+
+	stemmer := i.stemmer
+	if languageStemmer, ok := Stemmers[lang]; ok {
+		stemmer = languageStemmer
+	}
+
+	if stemmer != nil {
+		return stemmer(token), true
+	}
+
+	return token, true
+}
+
+func (i *TfIdf) addDocument(doc Document) {
+	if old, ok := i.Documents[doc.ID]; ok {
+		i.index.totalLength -= len(old.AllTokens)
+		i.index.totalUnique -= len(old.UniqueTokens)
+		i.index.countLanguage(old.Language, -1)
+	}
+	i.index.totalLength += len(doc.AllTokens)
+	i.index.totalUnique += len(doc.UniqueTokens)
+	i.index.countLanguage(doc.Language, 1)
 
+	i.index.add(doc)
+	for _, token := range doc.UniqueTokens {
 		if _, ok := i.termToIndex[token]; !ok {
 			i.termToIndex[token] = len(i.termToIndex)
+			i.vocabulary = append(i.vocabulary, token)
 		}
 	}
 
-	i.Documents[hash] = Document{
-		AllTokens:    allTokens,
-		UniqueTokens: uniqueTokens,
-		TermCount:    termCount,
+	i.Documents[doc.ID] = doc
+}
+
+func (i *TfIdf) removeDocument(doc Document) {
+	i.index.totalLength -= len(doc.AllTokens)
+	i.index.totalUnique -= len(doc.UniqueTokens)
+	i.index.countLanguage(doc.Language, -1)
+	delete(i.Documents, doc.ID)
+	i.dropTerms(i.index.remove(doc.ID, doc.UniqueTokens))
+}
+
+// dropTerms removes terms that are no longer contained in any document from
+// the vocabulary. The last term of the vocabulary moves to the index of every
+// dropped term, so that dropping a term does not reindex the others.
+func (i *TfIdf) dropTerms(terms []string) {
+	for _, term := range terms {
+		index, ok := i.termToIndex[term]
+		if !ok {
+			continue
+		}
+
+		last := i.vocabulary[len(i.vocabulary)-1]
+		i.vocabulary[index] = last
+		i.termToIndex[last] = index
+		i.vocabulary = i.vocabulary[:len(i.vocabulary)-1]
+		delete(i.termToIndex, term)
 	}
 }
 
+// compactTerms removes terms from the vocabulary, closing the gaps they leave
+// while preserving the relative order of the remaining terms.
+func (i *TfIdf) compactTerms(terms []string) {
+	for _, term := range terms {
+		delete(i.termToIndex, term)
+	}
+
+	vocabulary := i.vocabulary[:0]
+	for _, term := range i.vocabulary {
+		if _, ok := i.termToIndex[term]; ok {
+			i.termToIndex[term] = len(vocabulary)
+			vocabulary = append(vocabulary, term)
+		}
+	}
+	i.vocabulary = vocabulary
+}
+
+// vocabularyOf returns the terms of termToIndex ordered by index.
+func vocabularyOf(termToIndex map[string]int) []string {
+	vocabulary := make([]string, len(termToIndex))
+	for term, index := range termToIndex {
+		vocabulary[index] = term
+	}
+
+	return vocabulary
+}
+
 func md5Hash(s string) string {
 	h := md5.New()
 	h.Write([]byte(s))
@@ -1,8 +1,10 @@
 package go_tf_idf
 
 import (
+	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 )
 
@@ -166,6 +168,18 @@ func TestTfIdf_InverseDocumentFrequency(t *testing.T) {
 			term:      "example",
 			want:      0.3010299956639812,
 		},
+		{
+			name:      "unknown term inverse document frequency",
+			documents: []string{doc1Content, doc2Content},
+			term:      "asdf",
+			want:      0,
+		},
+		{
+			name:      "empty corpus inverse document frequency",
+			documents: []string{},
+			term:      "example",
+			want:      0,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -423,6 +437,19 @@ func TestTfIdf_TermFrequencyInverseDocumentFrequencyForDocument(t *testing.T) {
 			},
 			want: []float64{0, 0, 0, 0, 0.08600857018970891, 0.12901285528456335},
 		},
+		{
+			name: "doc1 with stopwords added first",
+			fields: fields{
+				Options: []Option{
+					WithDefaultStopWords(),
+					WithDocuments(documents),
+				},
+			},
+			args: args{
+				document: documents[0],
+			},
+			want: []float64{0.06020599913279624, 0, 0},
+		},
 		{
 			name: "nil document",
 			fields: fields{
@@ -445,3 +472,407 @@ func TestTfIdf_TermFrequencyInverseDocumentFrequencyForDocument(t *testing.T) {
 		})
 	}
 }
+
+func TestTfIdf_AddDocumentWithID(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		document string
+		wantErr  error
+	}{
+		{
+			name:     "add new document",
+			id:       "3",
+			document: doc2Content,
+			wantErr:  nil,
+		},
+		{
+			name:     "add existing id",
+			id:       "1",
+			document: doc2Content,
+			wantErr:  ErrDocumentExists,
+		},
+		{
+			name:     "add empty document",
+			id:       "3",
+			document: "",
+			wantErr:  ErrEmptyDocument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New()
+			if err := i.AddDocumentWithID("1", doc1Content); err != nil {
+				t.Fatalf("AddDocumentWithID() err = %v", err)
+			}
+
+			if err := i.AddDocumentWithID(tt.id, tt.document); err != tt.wantErr {
+				t.Errorf("AddDocumentWithID() err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTfIdf_ByID(t *testing.T) {
+	i := New()
+	_ = i.AddDocumentWithID("1", doc1Content)
+	_ = i.AddDocumentWithID("2", doc2Content)
+
+	doc := i.GetDocumentByID("1")
+	if doc == nil || doc.ID != "1" || !reflect.DeepEqual(doc.UniqueTokens, uniqueTokens1) {
+		t.Errorf("GetDocumentByID() = %v, want %v", doc, doc1)
+	}
+
+	if got := i.GetDocumentByID(md5Hash(doc1Content)); got != nil {
+		t.Errorf("GetDocumentByID() = %v, want nil", got)
+	}
+
+	if got := i.TermFrequencyInverseDocumentFrequencyForTermByID("example", "2"); got != 0.12901285528456335 {
+		t.Errorf("TermFrequencyInverseDocumentFrequencyForTermByID() = %v, want %v", got, 0.12901285528456335)
+	}
+
+	want := []float64{0, 0, 0.12041199826559248, 0.06020599913279624, 0, 0}
+	if got := i.TermFrequencyInverseDocumentFrequencyForDocumentByID("1"); !reflect.DeepEqual(got, want) {
+		t.Errorf("TermFrequencyInverseDocumentFrequencyForDocumentByID() = %v, want %v", got, want)
+	}
+
+	if got, err := i.CompareByID("1", "2"); err != nil || got != 0.19518001458970657 {
+		t.Errorf("CompareByID() = %v, %v, want %v", got, err, 0.19518001458970657)
+	}
+
+	if _, err := i.CompareByID("1", "3"); err != ErrDocumentNotFound {
+		t.Errorf("CompareByID() err = %v, want %v", err, ErrDocumentNotFound)
+	}
+}
+
+func TestTfIdf_RemoveDocumentByID(t *testing.T) {
+	tests := []struct {
+		name            string
+		id              string
+		wantErr         error
+		wantNumDocs     int
+		wantTermToIndex map[string]int
+		wantTermCounts  map[string]int
+	}{
+		{
+			name:        "remove doc1",
+			id:          "1",
+			wantErr:     nil,
+			wantNumDocs: 1,
+			wantTermToIndex: map[string]int{
+				"this": 0, "is": 1, "another": 3, "example": 2,
+			},
+			wantTermCounts: map[string]int{
+				"this": 1, "is": 1, "another": 1, "example": 1,
+			},
+		},
+		{
+			name:        "remove doc2",
+			id:          "2",
+			wantErr:     nil,
+			wantNumDocs: 1,
+			wantTermToIndex: map[string]int{
+				"this": 0, "is": 1, "a": 2, "sample": 3,
+			},
+			wantTermCounts: map[string]int{
+				"this": 1, "is": 1, "a": 1, "sample": 1,
+			},
+		},
+		{
+			name:        "remove unknown document",
+			id:          "3",
+			wantErr:     ErrDocumentNotFound,
+			wantNumDocs: 2,
+			wantTermToIndex: map[string]int{
+				"this": 0, "is": 1, "a": 2, "sample": 3, "another": 4, "example": 5,
+			},
+			wantTermCounts: map[string]int{
+				"this": 2, "is": 2, "a": 1, "sample": 1, "another": 1, "example": 1,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New()
+			_ = i.AddDocumentWithID("1", doc1Content)
+			_ = i.AddDocumentWithID("2", doc2Content)
+
+			if err := i.RemoveDocumentByID(tt.id); err != tt.wantErr {
+				t.Errorf("RemoveDocumentByID() err = %v, want %v", err, tt.wantErr)
+			}
+
+			if got := len(i.Documents); got != tt.wantNumDocs {
+				t.Errorf("len(documents) = %v, want %v", got, tt.wantNumDocs)
+			}
+
+			if !reflect.DeepEqual(i.termToIndex, tt.wantTermToIndex) {
+				t.Errorf("termToIndex = %v, want %v", i.termToIndex, tt.wantTermToIndex)
+			}
+			if !reflect.DeepEqual(i.vocabulary, vocabularyOf(i.termToIndex)) {
+				t.Errorf("vocabulary = %v, want %v", i.vocabulary, vocabularyOf(i.termToIndex))
+			}
+
+			termCounts := make(map[string]int)
+			for term := range i.index.postings {
+				termCounts[term] = i.index.documentFrequency(term)
+			}
+			if !reflect.DeepEqual(termCounts, tt.wantTermCounts) {
+				t.Errorf("document frequencies = %v, want %v", termCounts, tt.wantTermCounts)
+			}
+		})
+	}
+}
+
+func TestTfIdf_RemoveDocument(t *testing.T) {
+	i := New(
+		WithDocuments([]string{doc1Content, doc2Content}),
+	)
+	i.RemoveDocument(doc1Content)
+
+	if i.GetDocument(doc1Content) != nil {
+		t.Errorf("GetDocument() != nil after RemoveDocument()")
+	}
+
+	if got := i.InverseDocumentFrequency("this"); got != 0 {
+		t.Errorf("InverseDocumentFrequency() = %v, want %v", got, 0)
+	}
+}
+
+func TestTfIdf_UpdateDocument(t *testing.T) {
+	tests := []struct {
+		name            string
+		id              string
+		document        string
+		wantErr         error
+		wantUnique      []string
+		wantTermToIndex map[string]int
+	}{
+		{
+			name:       "update doc1",
+			id:         "1",
+			document:   "a new sample",
+			wantErr:    nil,
+			wantUnique: []string{"a", "new", "sample"},
+			wantTermToIndex: map[string]int{
+				"this": 0, "is": 1, "a": 2, "sample": 3, "another": 4, "example": 5, "new": 6,
+			},
+		},
+		{
+			name:       "update doc1 dropping terms",
+			id:         "1",
+			document:   "example",
+			wantErr:    nil,
+			wantUnique: []string{"example"},
+			wantTermToIndex: map[string]int{
+				"this": 0, "is": 1, "another": 3, "example": 2,
+			},
+		},
+		{
+			name:       "update with empty document",
+			id:         "1",
+			document:   "",
+			wantErr:    ErrEmptyDocument,
+			wantUnique: uniqueTokens1,
+			wantTermToIndex: map[string]int{
+				"this": 0, "is": 1, "a": 2, "sample": 3, "another": 4, "example": 5,
+			},
+		},
+		{
+			name:     "update unknown document",
+			id:       "3",
+			document: "new",
+			wantErr:  ErrDocumentNotFound,
+			wantTermToIndex: map[string]int{
+				"this": 0, "is": 1, "a": 2, "sample": 3, "another": 4, "example": 5,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New()
+			_ = i.AddDocumentWithID("1", doc1Content)
+			_ = i.AddDocumentWithID("2", doc2Content)
+
+			if err := i.UpdateDocument(tt.id, tt.document); err != tt.wantErr {
+				t.Errorf("UpdateDocument() err = %v, want %v", err, tt.wantErr)
+			}
+
+			if tt.wantUnique != nil {
+				if got := i.GetDocumentByID(tt.id).UniqueTokens; !reflect.DeepEqual(got, tt.wantUnique) {
+					t.Errorf("UniqueTokens = %v, want %v", got, tt.wantUnique)
+				}
+			}
+
+			if !reflect.DeepEqual(i.termToIndex, tt.wantTermToIndex) {
+				t.Errorf("termToIndex = %v, want %v", i.termToIndex, tt.wantTermToIndex)
+			}
+			if !reflect.DeepEqual(i.vocabulary, vocabularyOf(i.termToIndex)) {
+				t.Errorf("vocabulary = %v, want %v", i.vocabulary, vocabularyOf(i.termToIndex))
+			}
+		})
+	}
+}
+
+func TestTfIdf_CompareMode(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    float64
+	}{
+		{
+			name:    "term frequency",
+			options: []Option{},
+			want:    0.50709255283711,
+		},
+		{
+			name: "term frequency inverse document frequency",
+			options: []Option{
+				WithCompareMode(CompareTermFrequencyInverseDocumentFrequency),
+			},
+			want: 0.10477241822549672,
+		},
+		{
+			name: "term frequency inverse document frequency with weighting",
+			options: []Option{
+				WithCompareMode(CompareTermFrequencyInverseDocumentFrequency),
+				WithWeighting("nsn"),
+			},
+			want: 0.3561693631873323,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(tt.options...)
+			_ = i.AddDocumentWithID("1", doc1Content)
+			_ = i.AddDocumentWithID("2", doc2Content)
+			_ = i.AddDocumentWithID("3", "this sample is another example")
+
+			if got, err := i.CompareByID("1", "3"); err != nil || got != tt.want {
+				t.Errorf("CompareByID() = %v, %v, want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_SmoothInverseDocumentFrequency(t *testing.T) {
+	tests := []struct {
+		name      string
+		documents []string
+		term      string
+		want      float64
+	}{
+		{
+			name:      "'this' smooth inverse document frequency",
+			documents: []string{doc1Content, doc2Content},
+			term:      "this",
+			want:      1,
+		},
+		{
+			name:      "'example' smooth inverse document frequency",
+			documents: []string{doc1Content, doc2Content},
+			term:      "example",
+			want:      1.1760912590556813,
+		},
+		{
+			name:      "unknown term smooth inverse document frequency",
+			documents: []string{doc1Content, doc2Content},
+			term:      "asdf",
+			want:      1.4771212547196624,
+		},
+		{
+			name:      "empty corpus smooth inverse document frequency",
+			documents: []string{},
+			term:      "example",
+			want:      1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(
+				WithSmoothInverseDocumentFrequency(),
+				WithDocuments(tt.documents),
+			)
+			if got := i.InverseDocumentFrequency(tt.term); got != tt.want {
+				t.Errorf("InverseDocumentFrequency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_LookupInverseDocumentFrequency(t *testing.T) {
+	tests := []struct {
+		name      string
+		documents []string
+		term      string
+		want      float64
+		wantErr   error
+	}{
+		{
+			name:      "known term",
+			documents: []string{doc1Content, doc2Content},
+			term:      "example",
+			want:      0.3010299956639812,
+		},
+		{
+			name:      "unknown term",
+			documents: []string{doc1Content, doc2Content},
+			term:      "asdf",
+			wantErr:   ErrUnknownTerm,
+		},
+		{
+			name:      "empty corpus",
+			documents: []string{},
+			term:      "example",
+			wantErr:   ErrEmptyCorpus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(
+				WithDocuments(tt.documents),
+			)
+			got, err := i.LookupInverseDocumentFrequency(tt.term)
+			if err != tt.wantErr {
+				t.Errorf("LookupInverseDocumentFrequency() err = %v, want %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("LookupInverseDocumentFrequency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_Concurrent(t *testing.T) {
+	i := New(
+		WithDocuments([]string{doc1Content, doc2Content}),
+	)
+
+	var wg sync.WaitGroup
+	for n := 0; n < 8; n++ {
+		wg.Add(1)
+		go func(n int) {
+			defer wg.Done()
+			for m := 0; m < 50; m++ {
+				id := fmt.Sprintf("%d-%d", n, m)
+				_ = i.AddDocumentWithID(id, fmt.Sprintf("document %d written by %d", m, n))
+				_, _ = i.Compare(doc1Content, doc2Content)
+				_, _ = i.CompareByID(id, md5Hash(doc1Content))
+				_ = i.TermFrequencyInverseDocumentFrequencyForTerm("example", doc2Content)
+				_ = i.TermFrequencyInverseDocumentFrequencyForDocumentByID(id)
+				_ = i.InverseDocumentFrequency("document")
+				_ = i.Search("document written", 5)
+				if m%2 == 0 {
+					_ = i.UpdateDocument(id, "updated document")
+				} else {
+					_ = i.RemoveDocumentByID(id)
+				}
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if got, want := len(i.Documents), 2+8*25; got != want {
+		t.Errorf("len(documents) = %v, want %v", got, want)
+	}
+}
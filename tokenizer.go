@@ -1,6 +1,9 @@
 package go_tf_idf
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+)
 
 func Tokenize(s string) []string {
 	s = strings.ToLower(s)
@@ -13,3 +16,194 @@ func Tokenize(s string) []string {
 	s = strings.ReplaceAll(s, "/", " ")
 	return strings.Fields(s)
 }
+
+// TokenizeUnicode lowercases s and splits it into words following the
+// Unicode word boundary rules of UAX #29. Apostrophes and periods between
+// letters and decimal and thousands separators between digits do not break
+// words. URLs and email addresses are kept as single tokens. Punctuation,
+// symbols and emoji are dropped, and every Han or Hiragana character is a
+// token of its own.
+func TokenizeUnicode(s string) []string {
+	tokens := make([]string, 0)
+	for _, field := range strings.Fields(s) {
+		trimmed := strings.TrimLeftFunc(field, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		trimmed = strings.TrimRight(trimmed, ".,;:!?'\"”’)]}>")
+		if isURL(trimmed) || isEmail(trimmed) {
+			tokens = append(tokens, strings.ToLower(trimmed))
+			continue
+		}
+
+		tokens = appendWords(tokens, []rune(field))
+	}
+
+	return tokens
+}
+
+func appendWords(tokens []string, runes []rune) []string {
+	start := -1
+	for index := 0; index <= len(runes); index++ {
+		if index < len(runes) && start >= 0 && continuesWord(runes, index) {
+			continue
+		}
+
+		if start >= 0 {
+			tokens = append(tokens, strings.ToLower(string(runes[start:index])))
+			start = -1
+		}
+
+		if index == len(runes) {
+			break
+		}
+
+		r := runes[index]
+		if isIdeographic(r) {
+			tokens = append(tokens, string(r))
+		} else if isWordRune(r) {
+			start = index
+		}
+	}
+
+	return tokens
+}
+
+// continuesWord reports whether runes[index] belongs to the word containing
+// runes[index-1].
+func continuesWord(runes []rune, index int) bool {
+	r := runes[index]
+	prev := runes[index-1]
+	if isIdeographic(r) || isIdeographic(prev) {
+		return false
+	}
+
+	if isWordRune(r) {
+		return true
+	}
+
+	if index+1 >= len(runes) {
+		return false
+	}
+
+	next := runes[index+1]
+	switch r {
+	case '\'', '’', '·':
+		return unicode.IsLetter(prev) && unicode.IsLetter(next)
+	case '.':
+		return (unicode.IsLetter(prev) && unicode.IsLetter(next)) ||
+			(unicode.IsDigit(prev) && unicode.IsDigit(next))
+	case ',', '٫', '٬':
+		return unicode.IsDigit(prev) && unicode.IsDigit(next)
+	}
+
+	return false
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '_'
+}
+
+func isIdeographic(r rune) bool {
+	return unicode.Is(unicode.Han, r) || unicode.Is(unicode.Hiragana, r)
+}
+
+func isURL(s string) bool {
+	if strings.HasPrefix(strings.ToLower(s), "www.") {
+		return len(s) > len("www.")
+	}
+
+	index := strings.Index(s, "://")
+	if index <= 0 || index+len("://") == len(s) {
+		return false
+	}
+
+	for position, r := range s[:index] {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || (position > 0 && (unicode.IsDigit(r) || strings.ContainsRune("+.-", r)))) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isEmail(s string) bool {
+	at := strings.IndexByte(s, '@')
+	if at <= 0 || at != strings.LastIndexByte(s, '@') {
+		return false
+	}
+
+	for _, r := range s[:at] {
+		if !isWordRune(r) && !strings.ContainsRune(".!#$%&'*+/=?^`{|}~-", r) {
+			return false
+		}
+	}
+
+	labels := strings.Split(s[at+1:], ".")
+	if len(labels) < 2 {
+		return false
+	}
+
+	for _, label := range labels {
+		if label == "" {
+			return false
+		}
+
+		for _, r := range label {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// CharNGramTokenizer lowercases text and splits it into overlapping character
+// n-grams of Min to Max runes, which makes matching tolerant to typos and
+// inconsistent spacing. Runs of whitespace count as a single space. With
+// WordBoundaries set, n-grams are only taken from within words padded with a
+// space on both sides, and a padded word shorter than an n-gram is taken
+// whole, as in the char_wb analyzer of scikit-learn.
+type CharNGramTokenizer struct {
+	Min            int
+	Max            int
+	WordBoundaries bool
+}
+
+func (t CharNGramTokenizer) Tokenize(s string) []string {
+	min, max := t.Min, t.Max
+	if min < 1 {
+		min = 1
+	}
+	if max < min {
+		max = min
+	}
+
+	words := strings.Fields(strings.ToLower(s))
+	tokens := make([]string, 0)
+	if !t.WordBoundaries {
+		return appendCharNGrams(tokens, []rune(strings.Join(words, " ")), min, max)
+	}
+
+	for _, word := range words {
+		runes := []rune(" " + word + " ")
+		if len(runes) < min {
+			tokens = append(tokens, string(runes))
+			continue
+		}
+
+		tokens = appendCharNGrams(tokens, runes, min, max)
+	}
+
+	return tokens
+}
+
+func appendCharNGrams(tokens []string, runes []rune, min, max int) []string {
+	for n := min; n <= max && n <= len(runes); n++ {
+		for start := 0; start+n <= len(runes); start++ {
+			tokens = append(tokens, string(runes[start:start+n]))
+		}
+	}
+
+	return tokens
+}
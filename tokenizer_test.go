@@ -80,3 +80,138 @@ func TestTokenize(t *testing.T) {
 		})
 	}
 }
+
+func TestTokenizeUnicode(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want []string
+	}{
+		{
+			name: "Empty",
+			s:    "",
+			want: []string{},
+		},
+		{
+			name: "Exclamation and quotes",
+			s:    `"Great!" she said, great.`,
+			want: []string{"great", "she", "said", "great"},
+		},
+		{
+			name: "Hyphens",
+			s:    "well-known state-of-the-art",
+			want: []string{"well", "known", "state", "of", "the", "art"},
+		},
+		{
+			name: "Apostrophes",
+			s:    "don't won’t 'quoted'",
+			want: []string{"don't", "won’t", "quoted"},
+		},
+		{
+			name: "Numbers",
+			s:    "pi is 3.14, 1,000 items cost $5.",
+			want: []string{"pi", "is", "3.14", "1,000", "items", "cost", "5"},
+		},
+		{
+			name: "Letters and digits",
+			s:    "ipv6 snake_case",
+			want: []string{"ipv6", "snake_case"},
+		},
+		{
+			name: "Emoji and symbols",
+			s:    "love it 😍 ★★★ → 10/10",
+			want: []string{"love", "it", "10", "10"},
+		},
+		{
+			name: "Non-ASCII letters and punctuation",
+			s:    "Über «café» naïve — ¿qué?",
+			want: []string{"über", "café", "naïve", "qué"},
+		},
+		{
+			name: "URLs",
+			s:    "see https://example.com/a?b=c, (www.Example.org).",
+			want: []string{"see", "https://example.com/a?b=c", "www.example.org"},
+		},
+		{
+			name: "Emails",
+			s:    "mail John.Doe+tag@Example.co.uk!",
+			want: []string{"mail", "john.doe+tag@example.co.uk"},
+		},
+		{
+			name: "Not an email",
+			s:    "@handle a@b",
+			want: []string{"handle", "a", "b"},
+		},
+		{
+			name: "Ideographs",
+			s:    "東京タワー",
+			want: []string{"東", "京", "タワー"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TokenizeUnicode(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TokenizeUnicode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCharNGramTokenizer_Tokenize(t *testing.T) {
+	tests := []struct {
+		name      string
+		tokenizer CharNGramTokenizer
+		s         string
+		want      []string
+	}{
+		{
+			name:      "across words",
+			tokenizer: CharNGramTokenizer{Min: 2, Max: 3},
+			s:         "Ab  c",
+			want:      []string{"ab", "b ", " c", "ab ", "b c"},
+		},
+		{
+			name:      "word boundaries",
+			tokenizer: CharNGramTokenizer{Min: 2, Max: 3, WordBoundaries: true},
+			s:         "Ab  c",
+			want:      []string{" a", "ab", "b ", " ab", "ab ", " c", "c ", " c "},
+		},
+		{
+			name:      "short word",
+			tokenizer: CharNGramTokenizer{Min: 4, Max: 5, WordBoundaries: true},
+			s:         "a",
+			want:      []string{" a "},
+		},
+		{
+			name:      "text shorter than min",
+			tokenizer: CharNGramTokenizer{Min: 3, Max: 3},
+			s:         "ab",
+			want:      []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.tokenizer.Tokenize(tt.s); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_CompareCharNGrams(t *testing.T) {
+	words := New(WithDocuments([]string{"wireless headphones", "wirelss headphnes"}))
+	chars := New(
+		WithTokenizer(CharNGramTokenizer{Min: 2, Max: 4, WordBoundaries: true}),
+		WithDocuments([]string{"wireless headphones", "wirelss headphnes"}),
+	)
+
+	wordSimilarity, _ := words.Compare("wireless headphones", "wirelss headphnes")
+	charSimilarity, err := chars.Compare("wireless headphones", "wirelss headphnes")
+	if err != nil {
+		t.Fatalf("Compare() error = %v", err)
+	}
+
+	if wordSimilarity != 0 || charSimilarity < 0.5 {
+		t.Errorf("Compare() = %v with words and %v with character n-grams", wordSimilarity, charSimilarity)
+	}
+}
@@ -0,0 +1,174 @@
+package go_tf_idf
+
+import (
+	"fmt"
+	"math"
+)
+
+// Weighting is a term weighting scheme in SMART notation. Each field holds
+// the letter of the variant used for the respective component:
+//
+//	TermFrequency:     n (natural), l (logarithm), a (augmented), b (boolean), L (log average)
+//	DocumentFrequency: n (none), t (idf), s (smoothed idf), p (probabilistic idf)
+//	Normalization:     n (none), c (cosine), u (pivoted unique)
+//
+// Logarithms are natural. The smoothed idf ln((1+N)/(1+df))+1 matches
+// scikit-learn's TfidfTransformer with smooth_idf enabled.
+type Weighting struct {
+	TermFrequency     byte
+	DocumentFrequency byte
+	Normalization     byte
+}
+
+// PivotSlope is the slope used by pivoted unique normalization.
+const PivotSlope = 0.2
+
+func ParseWeighting(notation string) (Weighting, error) {
+	if len(notation) != 3 {
+		return Weighting{}, fmt.Errorf("invalid weighting %q: expected three letters", notation)
+	}
+
+	w := Weighting{
+		TermFrequency:     notation[0],
+		DocumentFrequency: notation[1],
+		Normalization:     notation[2],
+	}
+	if !containsByte("nlabL", w.TermFrequency) {
+		return Weighting{}, fmt.Errorf("invalid weighting %q: unknown term frequency %q", notation, w.TermFrequency)
+	}
+	if !containsByte("ntsp", w.DocumentFrequency) {
+		return Weighting{}, fmt.Errorf("invalid weighting %q: unknown document frequency %q", notation, w.DocumentFrequency)
+	}
+	if !containsByte("ncu", w.Normalization) {
+		return Weighting{}, fmt.Errorf("invalid weighting %q: unknown normalization %q", notation, w.Normalization)
+	}
+
+	return w, nil
+}
+
+func (w Weighting) String() string {
+	return string([]byte{w.TermFrequency, w.DocumentFrequency, w.Normalization})
+}
+
+// WithWeighting selects the weighting scheme used by the term frequency
+// inverse document frequency methods. The option is ignored if notation is
+// invalid; notations from user input should be checked with ParseWeighting
+// and passed to WithWeightingScheme.
+func WithWeighting(notation string) Option {
+	return func(tfIdf *TfIdf) {
+		if w, err := ParseWeighting(notation); err == nil {
+			tfIdf.weighting = &w
+		}
+	}
+}
+
+func WithWeightingScheme(w Weighting) Option {
+	return func(tfIdf *TfIdf) {
+		tfIdf.weighting = &w
+	}
+}
+
+func (w Weighting) termFrequency(count int, doc Document) float64 {
+	if count == 0 {
+		return 0
+	}
+
+	tf := float64(count)
+	switch w.TermFrequency {
+	case 'l':
+		return 1 + math.Log(tf)
+	case 'a':
+		max := 0
+		for _, c := range doc.TermCount {
+			if c > max {
+				max = c
+			}
+		}
+		return 0.5 + 0.5*tf/float64(max)
+	case 'b':
+		return 1
+	case 'L':
+		total := 0
+		for _, c := range doc.TermCount {
+			total += c
+		}
+		average := float64(total) / float64(len(doc.TermCount))
+		return (1 + math.Log(tf)) / (1 + math.Log(average))
+	}
+
+	return tf
+}
+
+func (w Weighting) inverseDocumentFrequency(df, n int) float64 {
+	switch w.DocumentFrequency {
+	case 't':
+		if df == 0 {
+			return 0
+		}
+		return math.Log(float64(n) / float64(df))
+	case 's':
+		return math.Log(float64(1+n)/float64(1+df)) + 1
+	case 'p':
+		if df == 0 || df >= n {
+			return 0
+		}
+		return math.Log(float64(n-df) / float64(df))
+	}
+
+	return 1
+}
+
+// weights returns the weight of every term of doc under the configured
+// Weighting.
+func (i *TfIdf) weights(doc Document) map[string]float64 {
+	w := *i.weighting
+	weights := make(map[string]float64, len(doc.UniqueTokens))
+	for _, term := range doc.UniqueTokens {
+		tf := w.termFrequency(doc.TermCount[term], doc)
+		idf := i.appliedInverseDocumentFrequency(term)
+		weights[term] = tf * idf
+	}
+
+	norm := 1.0
+	switch w.Normalization {
+	case 'c':
+		sum := 0.0
+		for _, weight := range weights {
+			sum += weight * weight
+		}
+		norm = math.Sqrt(sum)
+	case 'u':
+		pivot := i.averageUniqueTerms()
+		norm = (1-PivotSlope)*pivot + PivotSlope*float64(len(doc.UniqueTokens))
+	}
+
+	if norm != 0 && norm != 1 {
+		for term := range weights {
+			weights[term] /= norm
+		}
+	}
+
+	return weights
+}
+
+func (i *TfIdf) averageUniqueTerms() float64 {
+	if i.frozen != nil {
+		return i.frozen.averageUniqueTerms
+	}
+
+	if len(i.Documents) == 0 {
+		return 0
+	}
+
+	return float64(i.index.totalUnique) / float64(len(i.Documents))
+}
+
+func containsByte(s string, b byte) bool {
+	for index := 0; index < len(s); index++ {
+		if s[index] == b {
+			return true
+		}
+	}
+
+	return false
+}
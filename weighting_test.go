@@ -0,0 +1,167 @@
+package go_tf_idf
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseWeighting(t *testing.T) {
+	tests := []struct {
+		notation string
+		want     Weighting
+		wantErr  bool
+	}{
+		{notation: "ltc", want: Weighting{'l', 't', 'c'}},
+		{notation: "nsc", want: Weighting{'n', 's', 'c'}},
+		{notation: "Lpu", want: Weighting{'L', 'p', 'u'}},
+		{notation: "lt", wantErr: true},
+		{notation: "xtc", wantErr: true},
+		{notation: "lxc", wantErr: true},
+		{notation: "ltx", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.notation, func(t *testing.T) {
+			got, err := ParseWeighting(tt.notation)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWeighting() err = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseWeighting() = %v, want %v", got, tt.want)
+			}
+
+			if !tt.wantErr && got.String() != tt.notation {
+				t.Errorf("String() = %v, want %v", got.String(), tt.notation)
+			}
+		})
+	}
+}
+
+func TestWithWeighting_Invalid(t *testing.T) {
+	i := New(WithWeighting("nsc"), WithWeighting("xyz"))
+	if i.weighting == nil || i.weighting.String() != "nsc" {
+		t.Errorf("WithWeighting() set weighting %v, want nsc", i.weighting)
+	}
+}
+
+func TestWeighting_termFrequency(t *testing.T) {
+	doc := Document{TermCount: doc2Freq}
+	tests := []struct {
+		letter byte
+		count  int
+		want   float64
+	}{
+		{letter: 'n', count: 3, want: 3},
+		{letter: 'l', count: 3, want: 2.0986122886681096},
+		{letter: 'a', count: 2, want: 0.8333333333333333},
+		{letter: 'b', count: 3, want: 1},
+		{letter: 'L', count: 3, want: 1.3455956940819354},
+		{letter: 'l', count: 0, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.letter), func(t *testing.T) {
+			w := Weighting{TermFrequency: tt.letter}
+			if got := w.termFrequency(tt.count, doc); got != tt.want {
+				t.Errorf("termFrequency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWeighting_inverseDocumentFrequency(t *testing.T) {
+	tests := []struct {
+		letter byte
+		df     int
+		n      int
+		want   float64
+	}{
+		{letter: 'n', df: 1, n: 4, want: 1},
+		{letter: 't', df: 1, n: 4, want: 1.3862943611198906},
+		{letter: 't', df: 0, n: 4, want: 0},
+		{letter: 's', df: 1, n: 4, want: 1.916290731874155},
+		{letter: 's', df: 0, n: 0, want: 1},
+		{letter: 'p', df: 1, n: 4, want: 1.0986122886681096},
+		{letter: 'p', df: 4, n: 4, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.letter), func(t *testing.T) {
+			w := Weighting{DocumentFrequency: tt.letter}
+			if got := w.inverseDocumentFrequency(tt.df, tt.n); got != tt.want {
+				t.Errorf("inverseDocumentFrequency() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTfIdf_WithWeighting(t *testing.T) {
+	tests := []struct {
+		name     string
+		notation string
+		id       string
+		want     []float64
+	}{
+		{
+			name:     "ntn doc2",
+			notation: "ntn",
+			id:       "2",
+			want:     []float64{0, 0, 0, 0, 1.3862943611198906, 2.0794415416798357},
+		},
+		{
+			name:     "bnn doc1",
+			notation: "bnn",
+			id:       "1",
+			want:     []float64{1, 1, 1, 1, 0, 0},
+		},
+		{
+			name:     "bnc doc1",
+			notation: "bnc",
+			id:       "1",
+			want:     []float64{0.5, 0.5, 0.5, 0.5, 0, 0},
+		},
+		{
+			name:     "bnu doc1",
+			notation: "bnu",
+			id:       "1",
+			want:     []float64{0.25, 0.25, 0.25, 0.25, 0, 0},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			i := New(
+				WithWeighting(tt.notation),
+			)
+			_ = i.AddDocumentWithID("1", doc1Content)
+			_ = i.AddDocumentWithID("2", doc2Content)
+
+			got := i.TermFrequencyInverseDocumentFrequencyForDocumentByID(tt.id)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TermFrequencyInverseDocumentFrequencyForDocumentByID() = %v, want %v", got, tt.want)
+			}
+
+			for term, index := range i.termToIndex {
+				if got := i.TermFrequencyInverseDocumentFrequencyForTermByID(term, tt.id); got != tt.want[index] {
+					t.Errorf("TermFrequencyInverseDocumentFrequencyForTermByID(%v) = %v, want %v", term, got, tt.want[index])
+				}
+			}
+		})
+	}
+}
+
+func TestTfIdf_WithWeightingCosine(t *testing.T) {
+	i := New(
+		WithWeighting("ltc"),
+		WithDocuments([]string{doc1Content, doc2Content, "a sample example"}),
+	)
+
+	for _, doc := range []string{doc1Content, doc2Content} {
+		sum := 0.0
+		for _, weight := range i.TermFrequencyInverseDocumentFrequencyForDocument(doc) {
+			sum += weight * weight
+		}
+
+		if math.Abs(sum-1) > 1e-12 {
+			t.Errorf("squared norm = %v, want 1", sum)
+		}
+	}
+}